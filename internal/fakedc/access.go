@@ -0,0 +1,45 @@
+package fakedc
+
+import (
+	"fmt"
+	"net/netip"
+
+	"google.golang.org/protobuf/proto"
+
+	dc "github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+// validateAccess checks CIDR blocks of cluster access settings.
+func validateAccess(a *dc.Access) error {
+	for _, b := range a.GetIpv4CidrBlocks().GetValues() {
+		if p, err := netip.ParsePrefix(b.Value); err != nil || !p.Addr().Is4() {
+			return invalidArgument("access.ipv4_cidr_blocks", fmt.Sprintf("%q is not an IPv4 CIDR block", b.Value))
+		}
+	}
+	for _, b := range a.GetIpv6CidrBlocks().GetValues() {
+		if p, err := netip.ParsePrefix(b.Value); err != nil || !p.Addr().Is6() {
+			return invalidArgument("access.ipv6_cidr_blocks", fmt.Sprintf("%q is not an IPv6 CIDR block", b.Value))
+		}
+	}
+	return nil
+}
+
+// mergeAccess replaces every list set in update, merge would append to them.
+func mergeAccess(dst, update *dc.Access) *dc.Access {
+	if update == nil {
+		return dst
+	}
+	if dst == nil {
+		return proto.Clone(update).(*dc.Access)
+	}
+	if update.Ipv4CidrBlocks != nil {
+		dst.Ipv4CidrBlocks = update.Ipv4CidrBlocks
+	}
+	if update.Ipv6CidrBlocks != nil {
+		dst.Ipv6CidrBlocks = update.Ipv6CidrBlocks
+	}
+	if update.DataServices != nil {
+		dst.DataServices = update.DataServices
+	}
+	return dst
+}
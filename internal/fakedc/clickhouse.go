@@ -0,0 +1,231 @@
+package fakedc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/doublecloud/go-genproto/doublecloud/clickhouse/v1"
+	dc "github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+const defaultClickhouseVersion = "23.8"
+
+// AddClickhouseCluster stores an alive cluster and returns its id,
+// missing connection info is filled in.
+func (s *Server) AddClickhouseCluster(c *clickhouse.Cluster) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c = proto.Clone(c).(*clickhouse.Cluster)
+	if c.Id == "" {
+		c.Id = s.newId("chc")
+	}
+	if c.ConnectionInfo == nil {
+		c.ConnectionInfo = clickhouseConnectionInfo(c.Id)
+	}
+	if c.PrivateConnectionInfo == nil {
+		c.PrivateConnectionInfo = clickhousePrivateConnectionInfo(c.Id)
+	}
+	c.Status = dc.ClusterStatus_CLUSTER_STATUS_ALIVE
+	s.clickhouses[c.Id] = c
+	return c.Id
+}
+
+type clickhouseService struct {
+	clickhouse.UnimplementedClusterServiceServer
+	s *Server
+}
+
+func (svc *clickhouseService) Get(ctx context.Context, rq *clickhouse.GetClusterRequest) (*clickhouse.Cluster, error) {
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	c, ok := svc.s.clickhouses[rq.ClusterId]
+	if !ok {
+		return nil, notFound("cluster", rq.ClusterId)
+	}
+	return clickhouseView(c), nil
+}
+
+func (svc *clickhouseService) List(ctx context.Context, rq *clickhouse.ListClustersRequest) (*clickhouse.ListClustersResponse, error) {
+	if err := requireFields("project_id", rq.ProjectId); err != nil {
+		return nil, err
+	}
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	rs := &clickhouse.ListClustersResponse{}
+	for _, id := range sortedKeys(svc.s.clickhouses) {
+		if c := svc.s.clickhouses[id]; c.ProjectId == rq.ProjectId {
+			rs.Clusters = append(rs.Clusters, clickhouseView(c))
+		}
+	}
+	return rs, nil
+}
+
+func (svc *clickhouseService) Create(ctx context.Context, rq *clickhouse.CreateClusterRequest) (*dc.Operation, error) {
+	err := requireFields(
+		"project_id", rq.ProjectId,
+		"name", rq.Name,
+		"cloud_type", rq.CloudType,
+		"region_id", rq.RegionId,
+		"network_id", rq.NetworkId,
+		"resources.clickhouse.resource_preset_id", rq.GetResources().GetClickhouse().GetResourcePresetId(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAccess(rq.Access); err != nil {
+		return nil, err
+	}
+
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.networks[rq.NetworkId]; !ok {
+		return nil, invalidArgument("network_id", fmt.Sprintf("network %s not found", rq.NetworkId))
+	}
+	for _, c := range s.clickhouses {
+		if c.ProjectId == rq.ProjectId && c.Name == rq.Name {
+			return nil, status.Errorf(codes.AlreadyExists, "cluster with name %q already exists", rq.Name)
+		}
+	}
+
+	id := s.newId("chc")
+	c := &clickhouse.Cluster{
+		Id:                    id,
+		ProjectId:             rq.ProjectId,
+		CloudType:             rq.CloudType,
+		RegionId:              rq.RegionId,
+		CreateTime:            timestamppb.Now(),
+		Name:                  rq.Name,
+		Description:           rq.Description,
+		Status:                dc.ClusterStatus_CLUSTER_STATUS_CREATING,
+		Version:               rq.Version,
+		Resources:             rq.Resources,
+		Access:                rq.Access,
+		Encryption:            rq.Encryption,
+		NetworkId:             rq.NetworkId,
+		ClickhouseConfig:      rq.ClickhouseConfig,
+		MaintenanceWindow:     rq.MaintenanceWindow,
+		ConnectionInfo:        clickhouseConnectionInfo(id),
+		PrivateConnectionInfo: clickhousePrivateConnectionInfo(id),
+	}
+	if c.Version == "" {
+		c.Version = defaultClickhouseVersion
+	}
+	if r := c.Resources.Clickhouse; r.ReplicaCount == nil {
+		r.ReplicaCount = wrapperspb.Int64(1)
+	}
+	if r := c.Resources.Clickhouse; r.ShardCount == nil {
+		r.ShardCount = wrapperspb.Int64(1)
+	}
+	s.clickhouses[id] = c
+	return s.startOperation(clickhouseOperationPrefix, c.ProjectId, id, "create cluster", func() error {
+		c.Status = dc.ClusterStatus_CLUSTER_STATUS_ALIVE
+		return nil
+	}), nil
+}
+
+func (svc *clickhouseService) Update(ctx context.Context, rq *clickhouse.UpdateClusterRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.clickhouses[rq.ClusterId]
+	if !ok {
+		return nil, notFound("cluster", rq.ClusterId)
+	}
+	if err := validateAccess(rq.Access); err != nil {
+		return nil, err
+	}
+	c.Status = dc.ClusterStatus_CLUSTER_STATUS_UPDATING
+	return s.startOperation(clickhouseOperationPrefix, c.ProjectId, c.Id, "update cluster", func() error {
+		if rq.Name != "" {
+			c.Name = rq.Name
+		}
+		if rq.Description != "" {
+			c.Description = rq.Description
+		}
+		if rq.Version != "" {
+			c.Version = rq.Version
+		}
+		c.Resources = mergeMessage(c.Resources, rq.Resources)
+		c.Access = mergeAccess(c.Access, rq.Access)
+		c.ClickhouseConfig = mergeMessage(c.ClickhouseConfig, rq.ClickhouseConfig)
+		c.MaintenanceWindow = mergeMessage(c.MaintenanceWindow, rq.MaintenanceWindow)
+		c.Status = dc.ClusterStatus_CLUSTER_STATUS_ALIVE
+		return nil
+	}), nil
+}
+
+func (svc *clickhouseService) Delete(ctx context.Context, rq *clickhouse.DeleteClusterRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.clickhouses[rq.ClusterId]
+	if !ok {
+		return nil, notFound("cluster", rq.ClusterId)
+	}
+	c.Status = dc.ClusterStatus_CLUSTER_STATUS_STOPPING
+	return s.startOperation(clickhouseOperationPrefix, c.ProjectId, c.Id, "delete cluster", func() error {
+		delete(s.clickhouses, c.Id)
+		return nil
+	}), nil
+}
+
+// clickhouseView returns a copy of the cluster without passwords of
+// integrations in the config, the real API doesn't return them either.
+func clickhouseView(c *clickhouse.Cluster) *clickhouse.Cluster {
+	c = proto.Clone(c).(*clickhouse.Cluster)
+	if config := c.ClickhouseConfig; config != nil {
+		if config.Kafka != nil {
+			config.Kafka.SaslPassword = nil
+		}
+		for _, topic := range config.KafkaTopics {
+			topic.SaslPassword = nil
+		}
+		if config.Rabbitmq != nil {
+			config.Rabbitmq.Password = nil
+		}
+	}
+	return c
+}
+
+func clickhouseConnectionInfo(id string) *clickhouse.ConnectionInfo {
+	host := fmt.Sprintf("%s.at.double.cloud", id)
+	return &clickhouse.ConnectionInfo{
+		Host:           host,
+		User:           "admin",
+		Password:       "password",
+		HttpsPort:      wrapperspb.Int64(8443),
+		TcpPortSecure:  wrapperspb.Int64(9440),
+		NativeProtocol: fmt.Sprintf("%s:9440", host),
+		HttpsUri:       fmt.Sprintf("https://%s:8443", host),
+		JdbcUri:        fmt.Sprintf("jdbc:clickhouse://%s:8443/default?ssl=true", host),
+		OdbcUri:        fmt.Sprintf("https://%s:8443", host),
+	}
+}
+
+func clickhousePrivateConnectionInfo(id string) *clickhouse.PrivateConnectionInfo {
+	host := fmt.Sprintf("%s.private.at.double.cloud", id)
+	return &clickhouse.PrivateConnectionInfo{
+		Host:           host,
+		User:           "admin",
+		Password:       "password",
+		HttpsPort:      wrapperspb.Int64(8443),
+		TcpPortSecure:  wrapperspb.Int64(9440),
+		NativeProtocol: fmt.Sprintf("%s:9440", host),
+		HttpsUri:       fmt.Sprintf("https://%s:8443", host),
+		JdbcUri:        fmt.Sprintf("jdbc:clickhouse://%s:8443/default?ssl=true", host),
+		OdbcUri:        fmt.Sprintf("https://%s:8443", host),
+	}
+}
@@ -0,0 +1,35 @@
+package fakedc
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// invalidArgument returns an error with field violation like the real API
+// validation does.
+func invalidArgument(field, description string) error {
+	st := status.Newf(codes.InvalidArgument, "invalid %s: %s", field, description)
+	st, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to attach details: %v", err)
+	}
+	return st.Err()
+}
+
+func notFound(kind, id string) error {
+	return status.Errorf(codes.NotFound, "%s %s not found", kind, id)
+}
+
+// requireFields returns invalidArgument for the first empty field,
+// fields are pairs of field name and value.
+func requireFields(fields ...string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i+1] == "" {
+			return invalidArgument(fields[i], "must be set")
+		}
+	}
+	return nil
+}
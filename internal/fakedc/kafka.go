@@ -0,0 +1,205 @@
+package fakedc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/doublecloud/go-genproto/doublecloud/kafka/v1"
+	dc "github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+const defaultKafkaVersion = "3.5"
+
+// AddKafkaCluster stores an alive cluster and returns its id,
+// missing connection info is filled in.
+func (s *Server) AddKafkaCluster(c *kafka.Cluster) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c = proto.Clone(c).(*kafka.Cluster)
+	if c.Id == "" {
+		c.Id = s.newId("kfk")
+	}
+	if c.ConnectionInfo == nil {
+		c.ConnectionInfo = kafkaConnectionInfo(c.Id)
+	}
+	if c.PrivateConnectionInfo == nil {
+		c.PrivateConnectionInfo = kafkaPrivateConnectionInfo(c.Id)
+	}
+	c.Status = dc.ClusterStatus_CLUSTER_STATUS_ALIVE
+	s.kafkas[c.Id] = c
+	return c.Id
+}
+
+type kafkaService struct {
+	kafka.UnimplementedClusterServiceServer
+	s *Server
+}
+
+func (svc *kafkaService) Get(ctx context.Context, rq *kafka.GetClusterRequest) (*kafka.Cluster, error) {
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	c, ok := svc.s.kafkas[rq.ClusterId]
+	if !ok {
+		return nil, notFound("cluster", rq.ClusterId)
+	}
+	return proto.Clone(c).(*kafka.Cluster), nil
+}
+
+func (svc *kafkaService) List(ctx context.Context, rq *kafka.ListClustersRequest) (*kafka.ListClustersResponse, error) {
+	if err := requireFields("project_id", rq.ProjectId); err != nil {
+		return nil, err
+	}
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	rs := &kafka.ListClustersResponse{}
+	for _, id := range sortedKeys(svc.s.kafkas) {
+		if c := svc.s.kafkas[id]; c.ProjectId == rq.ProjectId {
+			rs.Clusters = append(rs.Clusters, proto.Clone(c).(*kafka.Cluster))
+		}
+	}
+	return rs, nil
+}
+
+func (svc *kafkaService) Create(ctx context.Context, rq *kafka.CreateClusterRequest) (*dc.Operation, error) {
+	err := requireFields(
+		"project_id", rq.ProjectId,
+		"name", rq.Name,
+		"cloud_type", rq.CloudType,
+		"region_id", rq.RegionId,
+		"network_id", rq.NetworkId,
+		"resources.kafka.resource_preset_id", rq.GetResources().GetKafka().GetResourcePresetId(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAccess(rq.Access); err != nil {
+		return nil, err
+	}
+
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.networks[rq.NetworkId]; !ok {
+		return nil, invalidArgument("network_id", fmt.Sprintf("network %s not found", rq.NetworkId))
+	}
+	for _, c := range s.kafkas {
+		if c.ProjectId == rq.ProjectId && c.Name == rq.Name {
+			return nil, status.Errorf(codes.AlreadyExists, "cluster with name %q already exists", rq.Name)
+		}
+	}
+
+	id := s.newId("kfk")
+	c := &kafka.Cluster{
+		Id:                    id,
+		ProjectId:             rq.ProjectId,
+		CloudType:             rq.CloudType,
+		RegionId:              rq.RegionId,
+		CreateTime:            timestamppb.Now(),
+		Name:                  rq.Name,
+		Description:           rq.Description,
+		Status:                dc.ClusterStatus_CLUSTER_STATUS_CREATING,
+		Version:               rq.Version,
+		Resources:             rq.Resources,
+		Access:                rq.Access,
+		Encryption:            rq.Encryption,
+		NetworkId:             rq.NetworkId,
+		MaintenanceWindow:     rq.MaintenanceWindow,
+		KafkaConfig:           rq.KafkaConfig,
+		SchemaRegistryConfig:  rq.SchemaRegistryConfig,
+		RestApiConfig:         rq.RestApiConfig,
+		ConnectionInfo:        kafkaConnectionInfo(id),
+		PrivateConnectionInfo: kafkaPrivateConnectionInfo(id),
+	}
+	if c.Version == "" {
+		c.Version = defaultKafkaVersion
+	}
+	if r := c.Resources.Kafka; r.ZoneCount == nil {
+		r.ZoneCount = wrapperspb.Int64(1)
+	}
+	s.kafkas[id] = c
+	return s.startOperation(kafkaOperationPrefix, c.ProjectId, id, "create cluster", func() error {
+		c.Status = dc.ClusterStatus_CLUSTER_STATUS_ALIVE
+		return nil
+	}), nil
+}
+
+func (svc *kafkaService) Update(ctx context.Context, rq *kafka.UpdateClusterRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.kafkas[rq.ClusterId]
+	if !ok {
+		return nil, notFound("cluster", rq.ClusterId)
+	}
+	if err := validateAccess(rq.Access); err != nil {
+		return nil, err
+	}
+	c.Status = dc.ClusterStatus_CLUSTER_STATUS_UPDATING
+	return s.startOperation(kafkaOperationPrefix, c.ProjectId, c.Id, "update cluster", func() error {
+		if rq.Name != "" {
+			c.Name = rq.Name
+		}
+		if rq.Description != "" {
+			c.Description = rq.Description
+		}
+		if rq.Version != "" {
+			c.Version = rq.Version
+		}
+		c.Resources = mergeMessage(c.Resources, rq.Resources)
+		c.Access = mergeAccess(c.Access, rq.Access)
+		c.MaintenanceWindow = mergeMessage(c.MaintenanceWindow, rq.MaintenanceWindow)
+		c.KafkaConfig = mergeMessage(c.KafkaConfig, rq.KafkaConfig)
+		// switches are replaced, merge can't turn them off
+		if rq.SchemaRegistryConfig != nil {
+			c.SchemaRegistryConfig = rq.SchemaRegistryConfig
+		}
+		if rq.RestApiConfig != nil {
+			c.RestApiConfig = rq.RestApiConfig
+		}
+		c.Status = dc.ClusterStatus_CLUSTER_STATUS_ALIVE
+		return nil
+	}), nil
+}
+
+func (svc *kafkaService) Delete(ctx context.Context, rq *kafka.DeleteClusterRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.kafkas[rq.ClusterId]
+	if !ok {
+		return nil, notFound("cluster", rq.ClusterId)
+	}
+	c.Status = dc.ClusterStatus_CLUSTER_STATUS_STOPPING
+	return s.startOperation(kafkaOperationPrefix, c.ProjectId, c.Id, "delete cluster", func() error {
+		delete(s.kafkas, c.Id)
+		return nil
+	}), nil
+}
+
+func kafkaConnectionInfo(id string) *kafka.ConnectionInfo {
+	return &kafka.ConnectionInfo{
+		ConnectionString: fmt.Sprintf("%s.at.double.cloud:9091", id),
+		User:             "admin",
+		Password:         "password",
+	}
+}
+
+func kafkaPrivateConnectionInfo(id string) *kafka.PrivateConnectionInfo {
+	return &kafka.PrivateConnectionInfo{
+		ConnectionString: fmt.Sprintf("%s.private.at.double.cloud:9091", id),
+		User:             "admin",
+		Password:         "password",
+	}
+}
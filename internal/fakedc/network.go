@@ -0,0 +1,215 @@
+package fakedc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+	dc "github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+// AddNetwork stores an active network as is and returns its id.
+func (s *Server) AddNetwork(n *network.Network) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n = proto.Clone(n).(*network.Network)
+	if n.Id == "" {
+		n.Id = s.newId("vpc")
+	}
+	n.Status = network.Network_NETWORK_STATUS_ACTIVE
+	s.networks[n.Id] = n
+	return n.Id
+}
+
+type networkService struct {
+	network.UnimplementedNetworkServiceServer
+	s *Server
+}
+
+func (svc *networkService) Get(ctx context.Context, rq *network.GetNetworkRequest) (*network.Network, error) {
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	n, ok := svc.s.networks[rq.NetworkId]
+	if !ok {
+		return nil, notFound("network", rq.NetworkId)
+	}
+	return proto.Clone(n).(*network.Network), nil
+}
+
+func (svc *networkService) List(ctx context.Context, rq *network.ListNetworksRequest) (*network.ListNetworksResponse, error) {
+	if err := requireFields("project_id", rq.ProjectId); err != nil {
+		return nil, err
+	}
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	rs := &network.ListNetworksResponse{}
+	for _, id := range sortedKeys(svc.s.networks) {
+		if n := svc.s.networks[id]; n.ProjectId == rq.ProjectId {
+			rs.Networks = append(rs.Networks, proto.Clone(n).(*network.Network))
+		}
+	}
+	return rs, nil
+}
+
+func (svc *networkService) Create(ctx context.Context, rq *network.CreateNetworkRequest) (*dc.Operation, error) {
+	err := requireFields(
+		"project_id", rq.ProjectId,
+		"name", rq.Name,
+		"cloud_type", rq.CloudType,
+		"region_id", rq.RegionId,
+		"ipv4_cidr_block", rq.Ipv4CidrBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := net.ParseCIDR(rq.Ipv4CidrBlock); err != nil {
+		return nil, invalidArgument("ipv4_cidr_block", err.Error())
+	}
+
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.networkNameTaken(rq.ProjectId, rq.Name) {
+		return nil, status.Errorf(codes.AlreadyExists, "network with name %q already exists", rq.Name)
+	}
+	n := &network.Network{
+		Id:            s.newId("vpc"),
+		ProjectId:     rq.ProjectId,
+		CloudType:     rq.CloudType,
+		RegionId:      rq.RegionId,
+		CreateTime:    timestamppb.Now(),
+		Name:          rq.Name,
+		Description:   rq.Description,
+		Ipv4CidrBlock: rq.Ipv4CidrBlock,
+		Status:        network.Network_NETWORK_STATUS_CREATING,
+	}
+	s.networks[n.Id] = n
+	return s.startOperation("", n.ProjectId, n.Id, "create network", func() error {
+		n.Status = network.Network_NETWORK_STATUS_ACTIVE
+		return nil
+	}), nil
+}
+
+func (svc *networkService) Import(ctx context.Context, rq *network.ImportNetworkRequest) (*dc.Operation, error) {
+	aws := rq.GetAws()
+	if aws == nil {
+		return nil, invalidArgument("aws", "must be set")
+	}
+	err := requireFields(
+		"project_id", rq.ProjectId,
+		"name", rq.Name,
+		"aws.vpc_id", aws.VpcId,
+		"aws.region_id", aws.RegionId,
+		"aws.account_id", aws.AccountId,
+		"aws.iam_role_arn", aws.IamRoleArn,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(aws.IamRoleArn, fmt.Sprintf("arn:aws:iam::%s:role/", aws.AccountId)) {
+		return nil, invalidArgument("aws.iam_role_arn", fmt.Sprintf("must be a role of account %s", aws.AccountId))
+	}
+
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.networkNameTaken(rq.ProjectId, rq.Name) {
+		return nil, status.Errorf(codes.AlreadyExists, "network with name %q already exists", rq.Name)
+	}
+	id := s.newId("vpc")
+	resources := &network.AwsExternalResources{
+		VpcId:           aws.VpcId,
+		SecurityGroupId: fmt.Sprintf("sg-%s", id),
+		AccountId:       wrapperspb.String(aws.AccountId),
+		IamRoleArn:      wrapperspb.String(aws.IamRoleArn),
+	}
+	for _, zone := range []string{"a", "b", "c"} {
+		resources.Subnets = append(resources.Subnets, &network.AwsExternalResources_Subnet{
+			Id:     fmt.Sprintf("subnet-%s%s", id, zone),
+			ZoneId: aws.RegionId + zone,
+		})
+	}
+	n := &network.Network{
+		Id:          id,
+		ProjectId:   rq.ProjectId,
+		CloudType:   "aws",
+		RegionId:    aws.RegionId,
+		CreateTime:  timestamppb.Now(),
+		Name:        rq.Name,
+		Description: rq.Description,
+		// the real API takes it from the VPC, this is the AWS default one
+		Ipv4CidrBlock:     "172.31.0.0/16",
+		Status:            network.Network_NETWORK_STATUS_CREATING,
+		ExternalResources: &network.Network_Aws{Aws: resources},
+		IsExternal:        true,
+	}
+	s.networks[n.Id] = n
+	return s.startOperation("", n.ProjectId, n.Id, "import network", func() error {
+		n.Status = network.Network_NETWORK_STATUS_ACTIVE
+		return nil
+	}), nil
+}
+
+func (svc *networkService) Delete(ctx context.Context, rq *network.DeleteNetworkRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.networks[rq.NetworkId]
+	if !ok {
+		return nil, notFound("network", rq.NetworkId)
+	}
+	if s.networkInUse(n.Id) {
+		return nil, status.Errorf(codes.FailedPrecondition, "network %s is used by clusters or network connections", n.Id)
+	}
+	n.Status = network.Network_NETWORK_STATUS_DELETING
+	return s.startOperation("", n.ProjectId, n.Id, "delete network", func() error {
+		delete(s.networks, n.Id)
+		return nil
+	}), nil
+}
+
+// networkNameTaken reports whether the project has a network with the name.
+// Must be called with s.mu held.
+func (s *Server) networkNameTaken(projectId, name string) bool {
+	for _, n := range s.networks {
+		if n.ProjectId == projectId && n.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// networkInUse reports whether any cluster or connection is placed in the network.
+// Must be called with s.mu held.
+func (s *Server) networkInUse(id string) bool {
+	for _, c := range s.clickhouses {
+		if c.NetworkId == id {
+			return true
+		}
+	}
+	for _, c := range s.kafkas {
+		if c.NetworkId == id {
+			return true
+		}
+	}
+	for _, c := range s.connections {
+		if c.NetworkId == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package fakedc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+	dc "github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+// AcceptNetworkConnection activates a pending connection like the peer side
+// accepting the peering does.
+func (s *Server) AcceptNetworkConnection(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.connections[id]
+	if !ok {
+		return notFound("network connection", id)
+	}
+	if c.Status != network.NetworkConnection_NETWORK_CONNECTION_STATUS_PENDING {
+		return status.Errorf(codes.FailedPrecondition, "network connection %s is %s", id, c.Status)
+	}
+	c.Status = network.NetworkConnection_NETWORK_CONNECTION_STATUS_ACTIVE
+	return nil
+}
+
+// AcceptPendingNetworkConnections activates all pending connections.
+func (s *Server) AcceptPendingNetworkConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.connections {
+		if c.Status == network.NetworkConnection_NETWORK_CONNECTION_STATUS_PENDING {
+			c.Status = network.NetworkConnection_NETWORK_CONNECTION_STATUS_ACTIVE
+		}
+	}
+}
+
+type networkConnectionService struct {
+	network.UnimplementedNetworkConnectionServiceServer
+	s *Server
+}
+
+func (svc *networkConnectionService) Get(ctx context.Context, rq *network.GetNetworkConnectionRequest) (*network.NetworkConnection, error) {
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	c, ok := svc.s.connections[rq.NetworkConnectionId]
+	if !ok {
+		return nil, notFound("network connection", rq.NetworkConnectionId)
+	}
+	return proto.Clone(c).(*network.NetworkConnection), nil
+}
+
+func (svc *networkConnectionService) List(ctx context.Context, rq *network.ListNetworkConnectionsRequest) (*network.ListNetworkConnectionsResponse, error) {
+	if err := requireFields("project_id", rq.ProjectId); err != nil {
+		return nil, err
+	}
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	rs := &network.ListNetworkConnectionsResponse{}
+	for _, id := range sortedKeys(svc.s.connections) {
+		c := svc.s.connections[id]
+		if n, ok := svc.s.networks[c.NetworkId]; ok && n.ProjectId == rq.ProjectId {
+			rs.NetworkConnections = append(rs.NetworkConnections, proto.Clone(c).(*network.NetworkConnection))
+		}
+	}
+	return rs, nil
+}
+
+func (svc *networkConnectionService) Create(ctx context.Context, rq *network.CreateNetworkConnectionRequest) (*dc.Operation, error) {
+	if err := requireFields("network_id", rq.NetworkId); err != nil {
+		return nil, err
+	}
+
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.networks[rq.NetworkId]
+	if !ok {
+		return nil, notFound("network", rq.NetworkId)
+	}
+	c := &network.NetworkConnection{
+		Id:          s.newId("ncn"),
+		NetworkId:   n.Id,
+		CreateTime:  timestamppb.Now(),
+		Description: rq.Description,
+		Status:      network.NetworkConnection_NETWORK_CONNECTION_STATUS_CREATING,
+	}
+	switch p := rq.Params.(type) {
+	case *network.CreateNetworkConnectionRequest_Aws:
+		peering := p.Aws.GetPeering()
+		if peering == nil {
+			return nil, invalidArgument("aws.peering", "must be set")
+		}
+		err := requireFields(
+			"aws.peering.vpc_id", peering.VpcId,
+			"aws.peering.account_id", peering.AccountId,
+			"aws.peering.region_id", peering.RegionId,
+			"aws.peering.ipv4_cidr_block", peering.Ipv4CidrBlock,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if _, _, err := net.ParseCIDR(peering.Ipv4CidrBlock); err != nil {
+			return nil, invalidArgument("aws.peering.ipv4_cidr_block", err.Error())
+		}
+		c.ConnectionInfo = &network.NetworkConnection_Aws{Aws: &network.AWSNetworkConnectionInfo{
+			Type: &network.AWSNetworkConnectionInfo_Peering{Peering: &network.AWSNetworkConnectionPeeringInfo{
+				VpcId:                peering.VpcId,
+				AccountId:            peering.AccountId,
+				RegionId:             peering.RegionId,
+				Ipv4CidrBlock:        peering.Ipv4CidrBlock,
+				Ipv6CidrBlock:        peering.Ipv6CidrBlock,
+				ManagedIpv4CidrBlock: n.Ipv4CidrBlock,
+			}},
+		}}
+	case *network.CreateNetworkConnectionRequest_Google:
+		err := requireFields(
+			"google.name", p.Google.GetName(),
+			"google.peer_network_url", p.Google.GetPeerNetworkUrl(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		c.ConnectionInfo = &network.NetworkConnection_Google{Google: &network.GoogleNetworkConnectionInfo{
+			Name:              p.Google.Name,
+			PeerNetworkUrl:    p.Google.PeerNetworkUrl,
+			ManagedNetworkUrl: fmt.Sprintf("projects/fake-dc/global/networks/%s", n.Id),
+		}}
+	default:
+		return nil, invalidArgument("params", "one of aws or google is required")
+	}
+	s.connections[c.Id] = c
+	return s.startOperation("", n.ProjectId, c.Id, "create network connection", func() error {
+		// the peer side has to accept the connection before it becomes active
+		if peering := c.GetAws().GetPeering(); peering != nil {
+			peering.PeeringConnectionId = fmt.Sprintf("pcx-%s", c.Id)
+		}
+		c.Status = network.NetworkConnection_NETWORK_CONNECTION_STATUS_PENDING
+		return nil
+	}), nil
+}
+
+func (svc *networkConnectionService) Delete(ctx context.Context, rq *network.DeleteNetworkConnectionRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.connections[rq.NetworkConnectionId]
+	if !ok {
+		return nil, notFound("network connection", rq.NetworkConnectionId)
+	}
+	c.Status = network.NetworkConnection_NETWORK_CONNECTION_STATUS_DELETING
+	return s.startOperation("", s.networks[c.NetworkId].GetProjectId(), c.Id, "delete network connection", func() error {
+		delete(s.connections, c.Id)
+		return nil
+	}), nil
+}
@@ -0,0 +1,129 @@
+package fakedc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/doublecloud/go-genproto/doublecloud/clickhouse/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/kafka/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1"
+	dc "github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+// Operation id prefixes used by SDK to choose operation service,
+// network and visualization operations use UUIDs.
+const (
+	clickhouseOperationPrefix = "cho"
+	kafkaOperationPrefix      = "kfo"
+	transferOperationPrefix   = "dtj"
+	endpointOperationPrefix   = "dte"
+)
+
+type operation struct {
+	proto *dc.Operation
+	polls int
+	// done applies the result of the operation to resources,
+	// returned error fails the operation.
+	done func() error
+}
+
+// startOperation registers a running operation over resourceId.
+// Must be called with s.mu held.
+func (s *Server) startOperation(prefix, projectId, resourceId, description string, done func() error) *dc.Operation {
+	id := uuid.NewString()
+	if prefix != "" {
+		id = s.newId(prefix)
+	}
+	op := &operation{
+		proto: &dc.Operation{
+			Id:          id,
+			ProjectId:   projectId,
+			Description: description,
+			CreatedBy:   "fake",
+			CreateTime:  timestamppb.Now(),
+			StartTime:   timestamppb.Now(),
+			Status:      dc.Operation_STATUS_RUNNING,
+			ResourceId:  resourceId,
+		},
+		polls: s.OperationPolls,
+		done:  done,
+	}
+	s.operations[id] = op
+	if op.polls <= 0 {
+		op.finish()
+	}
+	return proto.Clone(op.proto).(*dc.Operation)
+}
+
+func (op *operation) finish() {
+	if op.done != nil {
+		if err := op.done(); err != nil {
+			op.proto.Error = status.Convert(err).Proto()
+		}
+	}
+	op.proto.Status = dc.Operation_STATUS_DONE
+	op.proto.FinishTime = timestamppb.Now()
+}
+
+func (s *Server) getOperation(ctx context.Context, id string) (*dc.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op, ok := s.operations[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "operation %s not found", id)
+	}
+	if op.proto.Status != dc.Operation_STATUS_DONE {
+		op.polls--
+		if op.polls <= 0 {
+			op.finish()
+		}
+	}
+	// don't make clients sleep between polls
+	grpc.SetHeader(ctx, metadata.Pairs("x-operation-poll-interval", "0"))
+	return proto.Clone(op.proto).(*dc.Operation), nil
+}
+
+type networkOperationService struct {
+	network.UnimplementedOperationServiceServer
+	s *Server
+}
+
+func (svc *networkOperationService) Get(ctx context.Context, rq *network.GetOperationRequest) (*dc.Operation, error) {
+	return svc.s.getOperation(ctx, rq.OperationId)
+}
+
+type clickhouseOperationService struct {
+	clickhouse.UnimplementedOperationServiceServer
+	s *Server
+}
+
+func (svc *clickhouseOperationService) Get(ctx context.Context, rq *clickhouse.GetOperationRequest) (*dc.Operation, error) {
+	return svc.s.getOperation(ctx, rq.OperationId)
+}
+
+type kafkaOperationService struct {
+	kafka.UnimplementedOperationServiceServer
+	s *Server
+}
+
+func (svc *kafkaOperationService) Get(ctx context.Context, rq *kafka.GetOperationRequest) (*dc.Operation, error) {
+	return svc.s.getOperation(ctx, rq.OperationId)
+}
+
+type transferOperationService struct {
+	transfer.UnimplementedOperationServiceServer
+	s *Server
+}
+
+func (svc *transferOperationService) Get(ctx context.Context, rq *transfer.GetOperationRequest) (*dc.Operation, error) {
+	return svc.s.getOperation(ctx, rq.OperationId)
+}
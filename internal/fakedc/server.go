@@ -0,0 +1,181 @@
+// Package fakedc implements an in-memory DoubleCloud API for offline tests.
+//
+// The server keeps all resources in memory and completes operations after a
+// configurable number of polls, so clients go through the same
+// create/wait/get cycle as with the real API.
+package fakedc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/doublecloud/go-genproto/doublecloud/clickhouse/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/kafka/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/visualization/v1"
+)
+
+// Server serves Network, ClickHouse, Kafka, Transfer and Visualization
+// services on a single endpoint.
+type Server struct {
+	mu  sync.Mutex
+	seq int
+
+	// OperationPolls is a number of polls required to complete an operation.
+	OperationPolls int
+
+	operations  map[string]*operation
+	networks    map[string]*network.Network
+	connections map[string]*network.NetworkConnection
+	clickhouses map[string]*clickhouse.Cluster
+	kafkas      map[string]*kafka.Cluster
+	endpoints   map[string]*transfer.Endpoint
+	transfers   map[string]*transfer.Transfer
+	workbooks   map[string]*workbook
+	errors      map[string][]error
+
+	grpc *grpc.Server
+	lis  net.Listener
+}
+
+// New returns a server with no resources.
+func New() *Server {
+	s := &Server{
+		OperationPolls: 1,
+		operations:     map[string]*operation{},
+		networks:       map[string]*network.Network{},
+		connections:    map[string]*network.NetworkConnection{},
+		clickhouses:    map[string]*clickhouse.Cluster{},
+		kafkas:         map[string]*kafka.Cluster{},
+		endpoints:      map[string]*transfer.Endpoint{},
+		transfers:      map[string]*transfer.Transfer{},
+		workbooks:      map[string]*workbook{},
+		errors:         map[string][]error{},
+	}
+
+	s.grpc = grpc.NewServer(grpc.ChainUnaryInterceptor(s.authInterceptor, s.errorInterceptor))
+	network.RegisterNetworkServiceServer(s.grpc, &networkService{s: s})
+	network.RegisterNetworkConnectionServiceServer(s.grpc, &networkConnectionService{s: s})
+	network.RegisterOperationServiceServer(s.grpc, &networkOperationService{s: s})
+	clickhouse.RegisterClusterServiceServer(s.grpc, &clickhouseService{s: s})
+	clickhouse.RegisterOperationServiceServer(s.grpc, &clickhouseOperationService{s: s})
+	kafka.RegisterClusterServiceServer(s.grpc, &kafkaService{s: s})
+	kafka.RegisterOperationServiceServer(s.grpc, &kafkaOperationService{s: s})
+	transfer.RegisterEndpointServiceServer(s.grpc, &endpointService{s: s})
+	transfer.RegisterTransferServiceServer(s.grpc, &transferService{s: s})
+	transfer.RegisterOperationServiceServer(s.grpc, &transferOperationService{s: s})
+	visualization.RegisterWorkbookServiceServer(s.grpc, &workbookService{s: s})
+	return s
+}
+
+// Start listens on a random local port and serves in background.
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	s.lis = lis
+	go s.grpc.Serve(lis)
+	return nil
+}
+
+// Addr returns host:port the server listens on.
+func (s *Server) Addr() string {
+	return s.lis.Addr().String()
+}
+
+// Stop closes all connections and stops the server.
+func (s *Server) Stop() {
+	s.grpc.Stop()
+}
+
+// InjectError makes the next call of fullMethod, like
+// "/doublecloud.network.v1.NetworkService/Get", fail with err.
+// Errors injected for the same method are returned in order.
+func (s *Server) InjectError(fullMethod string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[fullMethod] = append(s.errors[fullMethod], err)
+}
+
+func (s *Server) errorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	s.mu.Lock()
+	errs := s.errors[info.FullMethod]
+	if len(errs) > 0 {
+		s.errors[info.FullMethod] = errs[1:]
+		s.mu.Unlock()
+		return nil, errs[0]
+	}
+	s.mu.Unlock()
+	return handler(ctx, req)
+}
+
+// authInterceptor requires a bearer token like the real API does, any token
+// is accepted.
+func (s *Server) authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		if strings.HasPrefix(v, "Bearer ") && len(v) > len("Bearer ") {
+			return handler(ctx, req)
+		}
+	}
+	return nil, status.Error(codes.Unauthenticated, "missing IAM token")
+}
+
+// newId returns a unique resource id with the given prefix.
+// Must be called with s.mu held.
+func (s *Server) newId(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s%017d", prefix, s.seq)
+}
+
+// sortedKeys returns map keys in order of creation, ids are sequential.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mergeMessage applies set fields of update to dst like partial updates of
+// the real API do.
+func mergeMessage[T proto.Message](dst, update T) T {
+	if !update.ProtoReflect().IsValid() {
+		return dst
+	}
+	update = proto.Clone(update).(T)
+	if !dst.ProtoReflect().IsValid() {
+		return update
+	}
+	mergeFields(dst.ProtoReflect(), update.ProtoReflect())
+	return dst
+}
+
+// mergeFields merges nested messages field by field, while wrappers, durations,
+// lists and maps replace current values, so unlike proto.Merge a wrapped false
+// or zero overrides the setting.
+func mergeFields(dst, update protoreflect.Message) {
+	update.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() != nil && !fd.IsList() && !fd.IsMap() && dst.Has(fd) &&
+			fd.Message().ParentFile().Package() != "google.protobuf" {
+			mergeFields(dst.Mutable(fd).Message(), v.Message())
+			return true
+		}
+		dst.Set(fd, v)
+		return true
+	})
+}
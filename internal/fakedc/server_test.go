@@ -0,0 +1,206 @@
+package fakedc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	dc "github.com/doublecloud/go-sdk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/doublecloud/go-genproto/doublecloud/clickhouse/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+)
+
+func testSDK(t *testing.T, s *Server) *dc.SDK {
+	t.Helper()
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Stop)
+
+	// SDK dials a separate address per service, all of them are served here
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", s.Addr())
+	})
+	sdk, err := dc.Build(context.Background(), dc.Config{
+		Credentials: dc.NewIAMTokenCredentials("token"),
+		Endpoint:    s.Addr(),
+		Plaintext:   true,
+	}, dialer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sdk.Shutdown(context.Background()) })
+	return sdk
+}
+
+func TestNetworkLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	s.OperationPolls = 2
+	sdk := testSDK(t, s)
+	svc := sdk.Network().Network()
+
+	op, err := sdk.WrapOperation(svc.Create(ctx, &network.CreateNetworkRequest{
+		ProjectId:     "project",
+		CloudType:     "aws",
+		RegionId:      "eu-central-1",
+		Name:          "net",
+		Ipv4CidrBlock: "10.0.0.0/16",
+	}))
+	if err != nil {
+		t.Fatalf("failed to create: %v", err)
+	}
+	if op.Done() {
+		t.Errorf("operation completed before polls")
+	}
+	n, err := svc.Get(ctx, &network.GetNetworkRequest{NetworkId: op.ResourceId()})
+	if err != nil || n.Status != network.Network_NETWORK_STATUS_CREATING {
+		t.Errorf("expected creating network, got %v (%v)", n, err)
+	}
+	if err := op.Wait(ctx); err != nil {
+		t.Fatalf("failed to wait: %v", err)
+	}
+	n, err = svc.Get(ctx, &network.GetNetworkRequest{NetworkId: op.ResourceId()})
+	if err != nil || n.Status != network.Network_NETWORK_STATUS_ACTIVE {
+		t.Errorf("expected active network, got %v (%v)", n, err)
+	}
+
+	_, err = svc.Create(ctx, &network.CreateNetworkRequest{ProjectId: "project", Name: "net", CloudType: "aws", RegionId: "eu-central-1", Ipv4CidrBlock: "10.1.0.0/16"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists for duplicate name, got %v", err)
+	}
+	_, err = svc.Create(ctx, &network.CreateNetworkRequest{ProjectId: "project", Name: "other", CloudType: "aws", RegionId: "eu-central-1", Ipv4CidrBlock: "10.1.0.0"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for bad CIDR, got %v", err)
+	}
+
+	rs, err := svc.List(ctx, &network.ListNetworksRequest{ProjectId: "project"})
+	if err != nil || len(rs.Networks) != 1 {
+		t.Errorf("expected one network, got %v (%v)", rs, err)
+	}
+
+	op, err = sdk.WrapOperation(svc.Delete(ctx, &network.DeleteNetworkRequest{NetworkId: n.Id}))
+	if err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if err := op.Wait(ctx); err != nil {
+		t.Fatalf("failed to wait: %v", err)
+	}
+	_, err = svc.Get(ctx, &network.GetNetworkRequest{NetworkId: n.Id})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound after delete, got %v", err)
+	}
+}
+
+func TestNetworkConnectionLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	sdk := testSDK(t, s)
+	svc := sdk.Network().NetworkConnection()
+	networkId := s.AddNetwork(&network.Network{ProjectId: "project", Name: "net", Ipv4CidrBlock: "10.0.0.0/16"})
+
+	_, err := svc.Create(ctx, &network.CreateNetworkConnectionRequest{NetworkId: networkId})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument without params, got %v", err)
+	}
+	op, err := sdk.WrapOperation(svc.Create(ctx, &network.CreateNetworkConnectionRequest{
+		NetworkId: networkId,
+		Params: &network.CreateNetworkConnectionRequest_Aws{Aws: &network.CreateAWSNetworkConnectionRequest{
+			Type: &network.CreateAWSNetworkConnectionRequest_Peering{Peering: &network.CreateAWSNetworkConnectionPeeringRequest{
+				VpcId:         "vpc-1",
+				AccountId:     "123456789012",
+				RegionId:      "eu-central-1",
+				Ipv4CidrBlock: "172.16.0.0/16",
+			}},
+		}},
+	}))
+	if err != nil {
+		t.Fatalf("failed to create: %v", err)
+	}
+	if err := op.Wait(ctx); err != nil {
+		t.Fatalf("failed to wait: %v", err)
+	}
+	c, err := svc.Get(ctx, &network.GetNetworkConnectionRequest{NetworkConnectionId: op.ResourceId()})
+	if err != nil || c.Status != network.NetworkConnection_NETWORK_CONNECTION_STATUS_PENDING {
+		t.Fatalf("expected pending connection, got %v (%v)", c, err)
+	}
+	if p := c.GetAws().GetPeering(); p.PeeringConnectionId == "" || p.ManagedIpv4CidrBlock != "10.0.0.0/16" {
+		t.Errorf("expected peering details, got %v", p)
+	}
+	if err := s.AcceptNetworkConnection(c.Id); err != nil {
+		t.Fatalf("failed to accept: %v", err)
+	}
+	c, err = svc.Get(ctx, &network.GetNetworkConnectionRequest{NetworkConnectionId: c.Id})
+	if err != nil || c.Status != network.NetworkConnection_NETWORK_CONNECTION_STATUS_ACTIVE {
+		t.Errorf("expected active connection, got %v (%v)", c, err)
+	}
+
+	_, err = sdk.Network().Network().Delete(ctx, &network.DeleteNetworkRequest{NetworkId: networkId})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition for connected network, got %v", err)
+	}
+	op, err = sdk.WrapOperation(svc.Delete(ctx, &network.DeleteNetworkConnectionRequest{NetworkConnectionId: c.Id}))
+	if err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if err := op.Wait(ctx); err != nil {
+		t.Fatalf("failed to wait: %v", err)
+	}
+	_, err = svc.Get(ctx, &network.GetNetworkConnectionRequest{NetworkConnectionId: c.Id})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound after delete, got %v", err)
+	}
+}
+
+func TestInjectError(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	sdk := testSDK(t, s)
+	id := s.AddNetwork(&network.Network{ProjectId: "project", Name: "net"})
+
+	s.InjectError("/doublecloud.network.v1.NetworkService/Get", status.Error(codes.Unavailable, "injected"))
+	_, err := sdk.Network().Network().Get(ctx, &network.GetNetworkRequest{NetworkId: id})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected injected error, got %v", err)
+	}
+	_, err = sdk.Network().Network().Get(ctx, &network.GetNetworkRequest{NetworkId: id})
+	if err != nil {
+		t.Errorf("expected injected error to be returned once, got %v", err)
+	}
+}
+
+func TestMergeMessage(t *testing.T) {
+	dst := &clickhouse.ClickhouseConfig{
+		LogLevel:       clickhouse.ClickhouseConfig_LOG_LEVEL_TRACE,
+		MaxConnections: wrapperspb.Int64(100),
+		MergeTree: &clickhouse.ClickhouseConfig_MergeTree{
+			PartsToThrowInsert: wrapperspb.Int64(300),
+			TtlOnlyDropParts:   wrapperspb.Bool(true),
+		},
+	}
+	dst = mergeMessage(dst, &clickhouse.ClickhouseConfig{
+		MergeTree: &clickhouse.ClickhouseConfig_MergeTree{
+			TtlOnlyDropParts:    wrapperspb.Bool(false),
+			MergeWithTtlTimeout: durationpb.New(time.Hour),
+		},
+	})
+
+	if dst.LogLevel != clickhouse.ClickhouseConfig_LOG_LEVEL_TRACE || dst.MaxConnections.GetValue() != 100 {
+		t.Errorf("expected unset fields to be kept, got %v", dst)
+	}
+	mt := dst.MergeTree
+	if mt.PartsToThrowInsert.GetValue() != 300 || mt.TtlOnlyDropParts == nil || mt.TtlOnlyDropParts.Value {
+		t.Errorf("expected nested wrappers to be replaced, got %v", mt)
+	}
+	if mt.MergeWithTtlTimeout.AsDuration() != time.Hour {
+		t.Errorf("expected duration to be set, got %v", mt.MergeWithTtlTimeout)
+	}
+}
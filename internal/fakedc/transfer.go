@@ -0,0 +1,289 @@
+package fakedc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1"
+	dc "github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+// AddEndpoint stores an endpoint as is and returns its id.
+func (s *Server) AddEndpoint(e *transfer.Endpoint) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e = proto.Clone(e).(*transfer.Endpoint)
+	if e.Id == "" {
+		e.Id = s.newId("dte")
+	}
+	s.endpoints[e.Id] = e
+	return e.Id
+}
+
+// AddTransfer stores a transfer as is and returns its id.
+func (s *Server) AddTransfer(t *transfer.Transfer) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t = proto.Clone(t).(*transfer.Transfer)
+	if t.Id == "" {
+		t.Id = s.newId("dtt")
+	}
+	s.transfers[t.Id] = t
+	return t.Id
+}
+
+type endpointService struct {
+	transfer.UnimplementedEndpointServiceServer
+	s *Server
+}
+
+func (svc *endpointService) Get(ctx context.Context, rq *transfer.GetEndpointRequest) (*transfer.Endpoint, error) {
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	e, ok := svc.s.endpoints[rq.EndpointId]
+	if !ok {
+		return nil, notFound("endpoint", rq.EndpointId)
+	}
+	return proto.Clone(e).(*transfer.Endpoint), nil
+}
+
+func (svc *endpointService) List(ctx context.Context, rq *transfer.ListEndpointsRequest) (*transfer.ListEndpointsResponse, error) {
+	if err := requireFields("project_id", rq.ProjectId); err != nil {
+		return nil, err
+	}
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	rs := &transfer.ListEndpointsResponse{}
+	for _, id := range sortedKeys(svc.s.endpoints) {
+		if e := svc.s.endpoints[id]; e.ProjectId == rq.ProjectId {
+			rs.Endpoints = append(rs.Endpoints, proto.Clone(e).(*transfer.Endpoint))
+		}
+	}
+	return rs, nil
+}
+
+func (svc *endpointService) Create(ctx context.Context, rq *transfer.CreateEndpointRequest) (*dc.Operation, error) {
+	if err := requireFields("project_id", rq.ProjectId, "name", rq.Name); err != nil {
+		return nil, err
+	}
+	if rq.GetSettings().GetSettings() == nil {
+		return nil, invalidArgument("settings", "must be set")
+	}
+
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := &transfer.Endpoint{
+		Id:          s.newId("dte"),
+		ProjectId:   rq.ProjectId,
+		Name:        rq.Name,
+		Description: rq.Description,
+		Labels:      rq.Labels,
+		Settings:    rq.Settings,
+	}
+	s.endpoints[e.Id] = e
+	return s.startOperation(endpointOperationPrefix, e.ProjectId, e.Id, "create endpoint", nil), nil
+}
+
+func (svc *endpointService) Update(ctx context.Context, rq *transfer.UpdateEndpointRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.endpoints[rq.EndpointId]
+	if !ok {
+		return nil, notFound("endpoint", rq.EndpointId)
+	}
+	if rq.Settings != nil && reflect.TypeOf(rq.Settings.GetSettings()) != reflect.TypeOf(e.Settings.GetSettings()) {
+		return nil, invalidArgument("settings", "endpoint type can't be changed")
+	}
+	return s.startOperation(endpointOperationPrefix, e.ProjectId, e.Id, "update endpoint", func() error {
+		if rq.Name != "" {
+			e.Name = rq.Name
+		}
+		if rq.Description != "" {
+			e.Description = rq.Description
+		}
+		if rq.Labels != nil {
+			e.Labels = rq.Labels
+		}
+		if rq.Settings != nil {
+			e.Settings = rq.Settings
+		}
+		return nil
+	}), nil
+}
+
+func (svc *endpointService) Delete(ctx context.Context, rq *transfer.DeleteEndpointRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.endpoints[rq.EndpointId]
+	if !ok {
+		return nil, notFound("endpoint", rq.EndpointId)
+	}
+	for _, t := range s.transfers {
+		if t.Source.GetId() == e.Id || t.Target.GetId() == e.Id {
+			return nil, status.Errorf(codes.FailedPrecondition, "endpoint %s is used by transfer %s", e.Id, t.Id)
+		}
+	}
+	return s.startOperation(endpointOperationPrefix, e.ProjectId, e.Id, "delete endpoint", func() error {
+		delete(s.endpoints, e.Id)
+		return nil
+	}), nil
+}
+
+type transferService struct {
+	transfer.UnimplementedTransferServiceServer
+	s *Server
+}
+
+func (svc *transferService) Get(ctx context.Context, rq *transfer.GetTransferRequest) (*transfer.Transfer, error) {
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	t, ok := svc.s.transfers[rq.TransferId]
+	if !ok {
+		return nil, notFound("transfer", rq.TransferId)
+	}
+	return proto.Clone(t).(*transfer.Transfer), nil
+}
+
+func (svc *transferService) List(ctx context.Context, rq *transfer.ListTransfersRequest) (*transfer.ListTransfersResponse, error) {
+	if err := requireFields("project_id", rq.ProjectId); err != nil {
+		return nil, err
+	}
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	rs := &transfer.ListTransfersResponse{}
+	for _, id := range sortedKeys(svc.s.transfers) {
+		if t := svc.s.transfers[id]; t.ProjectId == rq.ProjectId {
+			rs.Transfers = append(rs.Transfers, proto.Clone(t).(*transfer.Transfer))
+		}
+	}
+	return rs, nil
+}
+
+func (svc *transferService) Create(ctx context.Context, rq *transfer.CreateTransferRequest) (*dc.Operation, error) {
+	err := requireFields(
+		"project_id", rq.ProjectId,
+		"name", rq.Name,
+		"source_id", rq.SourceId,
+		"target_id", rq.TargetId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if rq.Type == transfer.TransferType_TRANSFER_TYPE_UNSPECIFIED {
+		return nil, invalidArgument("type", "must be set")
+	}
+
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source, ok := s.endpoints[rq.SourceId]
+	if !ok {
+		return nil, invalidArgument("source_id", fmt.Sprintf("endpoint %s not found", rq.SourceId))
+	}
+	target, ok := s.endpoints[rq.TargetId]
+	if !ok {
+		return nil, invalidArgument("target_id", fmt.Sprintf("endpoint %s not found", rq.TargetId))
+	}
+	t := &transfer.Transfer{
+		Id:          s.newId("dtt"),
+		ProjectId:   rq.ProjectId,
+		Name:        rq.Name,
+		Description: rq.Description,
+		Labels:      rq.Labels,
+		Source:      proto.Clone(source).(*transfer.Endpoint),
+		Target:      proto.Clone(target).(*transfer.Endpoint),
+		Status:      transfer.TransferStatus_CREATING,
+		Type:        rq.Type,
+	}
+	s.transfers[t.Id] = t
+	return s.startOperation(transferOperationPrefix, t.ProjectId, t.Id, "create transfer", func() error {
+		t.Status = transfer.TransferStatus_CREATED
+		return nil
+	}), nil
+}
+
+func (svc *transferService) Update(ctx context.Context, rq *transfer.UpdateTransferRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.transfers[rq.TransferId]
+	if !ok {
+		return nil, notFound("transfer", rq.TransferId)
+	}
+	return s.startOperation(transferOperationPrefix, t.ProjectId, t.Id, "update transfer", func() error {
+		if rq.Name != "" {
+			t.Name = rq.Name
+		}
+		if rq.Description != "" {
+			t.Description = rq.Description
+		}
+		if rq.Labels != nil {
+			t.Labels = rq.Labels
+		}
+		return nil
+	}), nil
+}
+
+func (svc *transferService) Activate(ctx context.Context, rq *transfer.ActivateTransferRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.transfers[rq.TransferId]
+	if !ok {
+		return nil, notFound("transfer", rq.TransferId)
+	}
+	return s.startOperation(transferOperationPrefix, t.ProjectId, t.Id, "activate transfer", func() error {
+		t.Status = transfer.TransferStatus_RUNNING
+		return nil
+	}), nil
+}
+
+func (svc *transferService) Deactivate(ctx context.Context, rq *transfer.DeactivateTransferRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.transfers[rq.TransferId]
+	if !ok {
+		return nil, notFound("transfer", rq.TransferId)
+	}
+	return s.startOperation(transferOperationPrefix, t.ProjectId, t.Id, "deactivate transfer", func() error {
+		t.Status = transfer.TransferStatus_STOPPED
+		return nil
+	}), nil
+}
+
+func (svc *transferService) Delete(ctx context.Context, rq *transfer.DeleteTransferRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.transfers[rq.TransferId]
+	if !ok {
+		return nil, notFound("transfer", rq.TransferId)
+	}
+	return s.startOperation(transferOperationPrefix, t.ProjectId, t.Id, "delete transfer", func() error {
+		delete(s.transfers, t.Id)
+		return nil
+	}), nil
+}
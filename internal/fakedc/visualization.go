@@ -0,0 +1,193 @@
+package fakedc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	dc "github.com/doublecloud/go-genproto/doublecloud/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/visualization/v1"
+)
+
+type workbook struct {
+	id          string
+	projectId   string
+	title       string
+	config      *structpb.Value
+	connections map[string]*visualization.Connection
+}
+
+type workbookService struct {
+	visualization.UnimplementedWorkbookServiceServer
+	s *Server
+}
+
+// getWorkbook must be called with s.mu held.
+func (s *Server) getWorkbook(id string) (*workbook, error) {
+	w, ok := s.workbooks[id]
+	if !ok {
+		return nil, notFound("workbook", id)
+	}
+	return w, nil
+}
+
+func (svc *workbookService) Get(ctx context.Context, rq *visualization.GetWorkbookRequest) (*visualization.GetWorkbookResponse, error) {
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	w, err := svc.s.getWorkbook(rq.WorkbookId)
+	if err != nil {
+		return nil, err
+	}
+	return &visualization.GetWorkbookResponse{
+		Workbook:  &visualization.Workbook{Config: proto.Clone(w.config).(*structpb.Value)},
+		Id:        w.id,
+		Title:     w.title,
+		ProjectId: w.projectId,
+	}, nil
+}
+
+func (svc *workbookService) ListWorkbooks(ctx context.Context, rq *visualization.ListWorkbooksRequest) (*visualization.ListWorkbooksResponse, error) {
+	if err := requireFields("project_id", rq.ProjectId); err != nil {
+		return nil, err
+	}
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	rs := &visualization.ListWorkbooksResponse{}
+	for _, id := range sortedKeys(svc.s.workbooks) {
+		if w := svc.s.workbooks[id]; w.projectId == rq.ProjectId {
+			rs.Workbooks = append(rs.Workbooks, &visualization.WorkbooksIndexItem{Id: w.id, Title: w.title})
+		}
+	}
+	return rs, nil
+}
+
+func (svc *workbookService) Create(ctx context.Context, rq *visualization.CreateWorkbookRequest) (*dc.Operation, error) {
+	if err := requireFields("project_id", rq.ProjectId, "workbook_title", rq.WorkbookTitle); err != nil {
+		return nil, err
+	}
+
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := &workbook{
+		id:          s.newId("wb"),
+		projectId:   rq.ProjectId,
+		title:       rq.WorkbookTitle,
+		config:      structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{}}),
+		connections: map[string]*visualization.Connection{},
+	}
+	s.workbooks[w.id] = w
+	return s.startOperation("", w.projectId, w.id, "create workbook", nil), nil
+}
+
+func (svc *workbookService) Update(ctx context.Context, rq *visualization.UpdateWorkbookRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, err := s.getWorkbook(rq.WorkbookId)
+	if err != nil {
+		return nil, err
+	}
+	if rq.GetWorkbook().GetConfig() == nil {
+		return nil, invalidArgument("workbook.config", "must be set")
+	}
+	return s.startOperation("", w.projectId, w.id, "update workbook", func() error {
+		w.config = rq.Workbook.Config
+		return nil
+	}), nil
+}
+
+func (svc *workbookService) Delete(ctx context.Context, rq *visualization.DeleteWorkbookRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, err := s.getWorkbook(rq.WorkbookId)
+	if err != nil {
+		return nil, err
+	}
+	return s.startOperation("", w.projectId, w.id, "delete workbook", func() error {
+		delete(s.workbooks, w.id)
+		return nil
+	}), nil
+}
+
+func (svc *workbookService) GetConnection(ctx context.Context, rq *visualization.GetWorkbookConnectionRequest) (*visualization.GetWorkbookConnectionResponse, error) {
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	w, err := svc.s.getWorkbook(rq.WorkbookId)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := w.connections[rq.ConnectionName]
+	if !ok {
+		return nil, notFound("connection", rq.ConnectionName)
+	}
+	return &visualization.GetWorkbookConnectionResponse{Connection: proto.Clone(c).(*visualization.Connection)}, nil
+}
+
+func (svc *workbookService) CreateConnection(ctx context.Context, rq *visualization.CreateWorkbookConnectionRequest) (*dc.Operation, error) {
+	if err := requireFields("connection_name", rq.ConnectionName); err != nil {
+		return nil, err
+	}
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, err := s.getWorkbook(rq.WorkbookId)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := w.connections[rq.ConnectionName]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "connection %q already exists", rq.ConnectionName)
+	}
+	return s.startOperation("", w.projectId, w.id, fmt.Sprintf("create connection %s", rq.ConnectionName), func() error {
+		w.connections[rq.ConnectionName] = rq.Connection
+		return nil
+	}), nil
+}
+
+func (svc *workbookService) UpdateConnection(ctx context.Context, rq *visualization.UpdateWorkbookConnectionRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, err := s.getWorkbook(rq.WorkbookId)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := w.connections[rq.ConnectionName]; !ok {
+		return nil, notFound("connection", rq.ConnectionName)
+	}
+	return s.startOperation("", w.projectId, w.id, fmt.Sprintf("update connection %s", rq.ConnectionName), func() error {
+		w.connections[rq.ConnectionName] = rq.Connection
+		return nil
+	}), nil
+}
+
+func (svc *workbookService) DeleteConnection(ctx context.Context, rq *visualization.DeleteWorkbookConnectionRequest) (*dc.Operation, error) {
+	s := svc.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, err := s.getWorkbook(rq.WorkbookId)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := w.connections[rq.ConnectionName]; !ok {
+		return nil, notFound("connection", rq.ConnectionName)
+	}
+	return s.startOperation("", w.projectId, w.id, fmt.Sprintf("delete connection %s", rq.ConnectionName), func() error {
+		delete(w.connections, rq.ConnectionName)
+		return nil
+	}), nil
+}
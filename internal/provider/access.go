@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+const accessDataServicePrefix = "DATA_SERVICE_"
+
+type accessModel struct {
+	DataServices   []types.String         `tfsdk:"data_services"`
+	Ipv4CidrBlocks []accessCidrBlockModel `tfsdk:"ipv4_cidr_blocks"`
+	Ipv6CidrBlocks []accessCidrBlockModel `tfsdk:"ipv6_cidr_blocks"`
+}
+
+type accessCidrBlockModel struct {
+	Value       types.String `tfsdk:"value"`
+	Description types.String `tfsdk:"description"`
+}
+
+func accessSchemaBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Access control of the cluster. Without the block the cluster keeps access settings it already has",
+		Attributes: map[string]schema.Attribute{
+			"data_services": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: fmt.Sprintf("DoubleCloud services allowed to access the cluster (%s)", strings.Join(enumNames(doublecloud.Access_DataService_name, accessDataServicePrefix), ", ")),
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(enumNames(doublecloud.Access_DataService_name, accessDataServicePrefix)...)),
+				},
+			},
+			"ipv4_cidr_blocks": accessCidrBlocksSchema(false),
+			"ipv6_cidr_blocks": accessCidrBlocksSchema(true),
+		},
+	}
+}
+
+func accessCidrBlocksSchema(ipv6 bool) schema.Attribute {
+	version := "IPv4"
+	if ipv6 {
+		version = "IPv6"
+	}
+	return schema.ListNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: fmt.Sprintf("%s CIDR blocks of external networks allowed to connect to the cluster", version),
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"value": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: fmt.Sprintf("%s CIDR block", version),
+					Validators:          []validator.String{accessCIDR{ipv6: ipv6}},
+				},
+				"description": schema.StringAttribute{
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString(""),
+					MarkdownDescription: "Description of the CIDR block",
+				},
+			},
+		},
+	}
+}
+
+// accessCIDR checks that the value is a CIDR block of the IP version.
+type accessCIDR struct {
+	ipv6 bool
+}
+
+func (v accessCIDR) Description(ctx context.Context) string {
+	if v.ipv6 {
+		return "value must be an IPv6 CIDR block, e.g. 2001:db8::/32"
+	}
+	return "value must be an IPv4 CIDR block, e.g. 203.0.113.0/24"
+}
+
+func (v accessCIDR) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v accessCIDR) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	p, err := netip.ParsePrefix(req.ConfigValue.ValueString())
+	if err != nil || p.Addr().Is6() != v.ipv6 {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid CIDR block", v.Description(ctx))
+		return
+	}
+	if p.Masked() != p {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid CIDR block",
+			fmt.Sprintf("%s has host bits set, use %s", p, p.Masked()))
+	}
+}
+
+func (m *accessModel) convert() (*doublecloud.Access, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if m == nil {
+		return nil, diags
+	}
+
+	// lists are always sent, so removing them from config clears them
+	rs := &doublecloud.Access{
+		Ipv4CidrBlocks: convertAccessCidrBlocks(m.Ipv4CidrBlocks),
+		Ipv6CidrBlocks: convertAccessCidrBlocks(m.Ipv6CidrBlocks),
+		DataServices:   &doublecloud.Access_DataServiceList{},
+	}
+	for _, s := range m.DataServices {
+		v, ok := doublecloud.Access_DataService_value[accessDataServicePrefix+strings.ToUpper(s.ValueString())]
+		if !ok {
+			diags.AddError("unknown data service", fmt.Sprintf("data service %q is not supported", s.ValueString()))
+			continue
+		}
+		rs.DataServices.Values = append(rs.DataServices.Values, doublecloud.Access_DataService(v))
+	}
+	return rs, diags
+}
+
+func convertAccessCidrBlocks(blocks []accessCidrBlockModel) *doublecloud.Access_CidrBlockList {
+	rs := &doublecloud.Access_CidrBlockList{}
+	for _, b := range blocks {
+		rs.Values = append(rs.Values, &doublecloud.Access_CidrBlock{
+			Value:       b.Value.ValueString(),
+			Description: b.Description.ValueString(),
+		})
+	}
+	return rs
+}
+
+// parseAccess returns access settings of the cluster. The block stays absent
+// if it isn't configured, since the cluster keeps its settings then, and on
+// import it is filled in unless the cluster has no settings.
+func parseAccess(m *accessModel, rs *doublecloud.Access, imported bool) *accessModel {
+	if m == nil {
+		empty := len(rs.GetDataServices().GetValues()) == 0 &&
+			len(rs.GetIpv4CidrBlocks().GetValues()) == 0 &&
+			len(rs.GetIpv6CidrBlocks().GetValues()) == 0
+		if !imported || empty {
+			return nil
+		}
+		m = &accessModel{}
+	}
+
+	var services []types.String
+	for _, s := range rs.GetDataServices().GetValues() {
+		services = append(services, types.StringValue(enumName(s.String(), accessDataServicePrefix)))
+	}
+	// empty lists in config stay empty instead of becoming null
+	if services != nil || m.DataServices == nil {
+		m.DataServices = services
+	}
+	m.Ipv4CidrBlocks = parseAccessCidrBlocks(m.Ipv4CidrBlocks, rs.GetIpv4CidrBlocks())
+	m.Ipv6CidrBlocks = parseAccessCidrBlocks(m.Ipv6CidrBlocks, rs.GetIpv6CidrBlocks())
+	return m
+}
+
+func parseAccessCidrBlocks(prior []accessCidrBlockModel, rs *doublecloud.Access_CidrBlockList) []accessCidrBlockModel {
+	if len(rs.GetValues()) == 0 && prior != nil {
+		return []accessCidrBlockModel{}
+	}
+	var blocks []accessCidrBlockModel
+	for _, b := range rs.GetValues() {
+		blocks = append(blocks, accessCidrBlockModel{
+			Value:       types.StringValue(b.Value),
+			Description: types.StringValue(b.Description),
+		})
+	}
+	return blocks
+}
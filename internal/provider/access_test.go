@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+func TestAccessCIDRValidator(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		value string
+		ipv6  bool
+		err   string
+	}{
+		{value: "0.0.0.0/0"},
+		{value: "203.0.113.7/32"},
+		{value: "2001:db8::/32", ipv6: true},
+		{value: "203.0.113.0", err: "must be an IPv4 CIDR block"},
+		{value: "2001:db8::/32", err: "must be an IPv4 CIDR block"},
+		{value: "203.0.113.0/24", ipv6: true, err: "must be an IPv6 CIDR block"},
+		{value: "203.0.113.1/24", err: "use 203.0.113.0/24"},
+	} {
+		rs := validator.StringResponse{}
+		accessCIDR{ipv6: tc.ipv6}.ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("value"),
+			ConfigValue: types.StringValue(tc.value),
+		}, &rs)
+		switch {
+		case tc.err == "" && rs.Diagnostics.HasError():
+			t.Errorf("%s: unexpected error %v", tc.value, rs.Diagnostics)
+		case tc.err != "" && (rs.Diagnostics.ErrorsCount() != 1 || !strings.Contains(rs.Diagnostics[0].Detail(), tc.err)):
+			t.Errorf("%s: expected error %q, got %v", tc.value, tc.err, rs.Diagnostics)
+		}
+	}
+}
+
+func TestAccessRoundTrip(t *testing.T) {
+	m := &accessModel{
+		DataServices:   []types.String{types.StringValue("transfer")},
+		Ipv4CidrBlocks: []accessCidrBlockModel{{Value: types.StringValue("203.0.113.0/24"), Description: types.StringValue("office")}},
+		Ipv6CidrBlocks: []accessCidrBlockModel{},
+	}
+	rs, diags := m.convert()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if v := rs.DataServices.Values; len(v) != 1 || v[0] != doublecloud.Access_DATA_SERVICE_TRANSFER {
+		t.Errorf("unexpected data services %v", v)
+	}
+	if rs.Ipv6CidrBlocks == nil {
+		t.Errorf("expected empty IPv6 list to be sent to clear it")
+	}
+
+	parsed := parseAccess(&accessModel{Ipv6CidrBlocks: []accessCidrBlockModel{}}, rs, false)
+	if len(parsed.DataServices) != 1 || parsed.DataServices[0].ValueString() != "transfer" {
+		t.Errorf("unexpected data services %v", parsed.DataServices)
+	}
+	if len(parsed.Ipv4CidrBlocks) != 1 || parsed.Ipv4CidrBlocks[0].Description.ValueString() != "office" {
+		t.Errorf("unexpected IPv4 blocks %v", parsed.Ipv4CidrBlocks)
+	}
+	if parsed.Ipv6CidrBlocks == nil {
+		t.Errorf("expected configured empty IPv6 list to stay empty")
+	}
+
+	if parseAccess(nil, rs, false) != nil {
+		t.Errorf("expected unconfigured access to stay absent")
+	}
+	if imported := parseAccess(nil, rs, true); imported == nil || len(imported.Ipv4CidrBlocks) != 1 {
+		t.Errorf("expected access to be imported, got %v", imported)
+	}
+	if parseAccess(nil, &doublecloud.Access{}, true) != nil {
+		t.Errorf("expected empty access not to be imported")
+	}
+}
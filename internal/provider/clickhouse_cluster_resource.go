@@ -3,22 +3,31 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/doublecloud/go-genproto/doublecloud/clickhouse/v1"
 	dcsdk "github.com/doublecloud/go-sdk"
 	dcgen "github.com/doublecloud/go-sdk/gen/clickhouse"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -32,17 +41,37 @@ type clickhouseClusterModel struct {
 	Description types.String                `tfsdk:"description"`
 	Version     types.String                `tfsdk:"version"`
 	Resources   *clickhouseClusterResources `tfsdk:"resources"`
-	// TODO: support access field
-	// https://github.com/doublecloud/api/blob/main/doublecloud/v1/cluster.proto
-	// Access            *clickhouseAccess           `tfsdk:"resources"`
+	Access      *accessModel                `tfsdk:"access"`
 	// Hide encryption due to deprecation
 	// Encryption *DataEncryptionModel `tfsdk:"encryption"`
-	NetworkId  types.String         `tfsdk:"network_id"`
-	Config     *clickhouseConfig    `tfsdk:"config"`
+	NetworkId types.String      `tfsdk:"network_id"`
+	Config    *clickhouseConfig `tfsdk:"config"`
 
-	// TODO: support mw
-	// https://github.com/doublecloud/api/blob/main/doublecloud/v1/maintenance.proto
-	// MaintenanceWindow *maintenanceWindow          `tfsdk:"maintenance_window"`
+	ConnectionInfo        types.Object `tfsdk:"connection_info"`
+	PrivateConnectionInfo types.Object `tfsdk:"private_connection_info"`
+
+	MaintenanceWindow *maintenanceWindowModel `tfsdk:"maintenance_window"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+var clickhouseConnectionInfoType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"host":            types.StringType,
+	"user":            types.StringType,
+	"password":        types.StringType,
+	"https_port":      types.Int64Type,
+	"tcp_port_secure": types.Int64Type,
+	"native_protocol": types.StringType,
+	"https_uri":       types.StringType,
+	"jdbc_uri":        types.StringType,
+	"odbc_uri":        types.StringType,
+}}
+
+var clickhouseTimeouts = resourceTimeouts{
+	Create: 60 * time.Minute,
+	Read:   5 * time.Minute,
+	Update: 60 * time.Minute,
+	Delete: 30 * time.Minute,
 }
 
 type clickhouseClusterResources struct {
@@ -88,64 +117,64 @@ type clickhouseClusterResourcesClickhouse struct {
 }
 
 type clickhouseConfig struct {
-	LogLevel                                  types.String  `tfsdk:"log_level"`
-	MaxConnections                            types.Int64   `tfsdk:"max_connections"`
-	MaxConcurrentQueries                      types.Int64   `tfsdk:"max_concurrent_queries"`
-	KeepAliveTimeout                          types.String  `tfsdk:"keep_alive_timeout"`
-	UncompressedCacheSize                     types.Int64   `tfsdk:"uncompressed_cache_size"`
-	MarkCacheSize                             types.Int64   `tfsdk:"mark_cache_size"`
-	MaxTableSizeToDrop                        types.Int64   `tfsdk:"max_table_size_to_drop"`
-	MaxPartitionSizeToDrop                    types.Int64   `tfsdk:"max_partition_size_to_drop"`
-	Timezone                                  types.String  `tfsdk:"timezone"`
-	BackgroundPoolSize                        types.Int64   `tfsdk:"background_pool_size"`
-	BackgroundSchedulePoolSize                types.Int64   `tfsdk:"background_schedule_pool_size"`
-	BackgroundFetchesPoolSize                 types.Int64   `tfsdk:"background_fetches_pool_size"`
-	BackgroundMovePoolSize                    types.Int64   `tfsdk:"background_move_pool_size"`
-	BackgroundCommonPoolSize                  types.Int64   `tfsdk:"background_common_pool_size"`
-	BackgroundMergesMutationsConcurrencyRatio types.Int64   `tfsdk:"background_merges_mutations_concurrency_ratio"`
-	TotalMemoryProfilerStep                   types.Int64   `tfsdk:"total_memory_profiler_step"`
-	TotalMemoryTrackerSampleProbability       types.Float64 `tfsdk:"total_memory_tracker_sample_probability"`
-	BackgroundMessageBrokerSchedulePoolSize   types.Int64   `tfsdk:"background_message_broker_schedule_pool_size"`
-	// MergeTree                                 *clickhouseConfigMergeTree    `tfsdk:"merge_tree"`
-	// Compression                               []clickhouseConfigCompression `tfsdk:"compression"`
-	// Kafka                                     *clickhouseConfigKafka        `tfsdk:"kafka"`
-	// KafkaTopics                               types.Map                     `tfsdk:"kafka_topics"`
-	// Rabbitmq                                  *clickhouseConfigRabbitmq     `tfsdk:"rabbitmq"`
-	QueryLogRetentionSize              types.Int64  `tfsdk:"query_log_retention_size"`
-	QueryLogRetentionTime              types.String `tfsdk:"query_log_retention_time"`
-	QueryThreadLogEnabled              types.Bool   `tfsdk:"query_thread_log_enabled"`
-	QueryThreadLogRetentionSize        types.Int64  `tfsdk:"query_thread_log_retention_size"`
-	QueryThreadLogRetentionTime        types.String `tfsdk:"query_thread_log_retention_time"`
-	QueryViewsLogEnabled               types.Bool   `tfsdk:"query_views_log_enabled"`
-	QueryViewsLogRetentionSize         types.Int64  `tfsdk:"query_views_log_retention_size"`
-	QueryViewsLogRetentionTime         types.String `tfsdk:"query_views_log_retention_time"`
-	PartLogRetentionSize               types.Int64  `tfsdk:"part_log_retention_size"`
-	PartLogRetentionTime               types.String `tfsdk:"part_log_retention_time"`
-	MetricLogEnabled                   types.Bool   `tfsdk:"metric_log_enabled"`
-	MetricLogRetentionSize             types.Int64  `tfsdk:"metric_log_retention_size"`
-	MetricLogRetentionTime             types.String `tfsdk:"metric_log_retention_time"`
-	AsynchronousMetricLogEnabled       types.Bool   `tfsdk:"asynchronous_metric_log_enabled"`
-	AsynchronousMetricLogRetentionSize types.Int64  `tfsdk:"asynchronous_metric_log_retention_size"`
-	AsynchronousMetricLogRetentionTime types.String `tfsdk:"asynchronous_metric_log_retention_time"`
-	TraceLogEnabled                    types.Bool   `tfsdk:"trace_log_enabled"`
-	TraceLogRetentionSize              types.Int64  `tfsdk:"trace_log_retention_size"`
-	TraceLogRetentionTime              types.String `tfsdk:"trace_log_retention_time"`
-	TextLogEnabled                     types.Bool   `tfsdk:"text_log_enabled"`
-	TextLogRetentionSize               types.Int64  `tfsdk:"text_log_retention_size"`
-	TextLogRetentionTime               types.String `tfsdk:"text_log_retention_time"`
-	TextLogLevel                       types.String `tfsdk:"text_log_level"`
-	OpentelemetrySpanLogEnabled        types.Bool   `tfsdk:"opentelemetry_span_log_enabled"`
-	OpentelemetrySpanLogRetentionSize  types.Int64  `tfsdk:"opentelemetry_span_log_retention_size"`
-	OpentelemetrySpanLogRetentionTime  types.String `tfsdk:"opentelemetry_span_log_retention_time"`
-	SessionLogEnabled                  types.Bool   `tfsdk:"session_log_enabled"`
-	SessionLogRetentionSize            types.Int64  `tfsdk:"session_log_retention_size"`
-	SessionLogRetentionTime            types.String `tfsdk:"session_log_retention_time"`
-	ZookeeperLogEnabled                types.Bool   `tfsdk:"zookeeper_log_enabled"`
-	ZookeeperLogRetentionSize          types.Int64  `tfsdk:"zookeeper_log_retention_size"`
-	ZookeeperLogRetentionTime          types.String `tfsdk:"zookeeper_log_retention_time"`
-	AsynchronousInsertLogEnabled       types.Bool   `tfsdk:"asynchronous_insert_log_enabled"`
-	AsynchronousInsertLogRetentionSize types.Int64  `tfsdk:"asynchronous_insert_log_retention_size"`
-	AsynchronousInsertLogRetentionTime types.String `tfsdk:"asynchronous_insert_log_retention_time"`
+	LogLevel                                  types.String                     `tfsdk:"log_level"`
+	MaxConnections                            types.Int64                      `tfsdk:"max_connections"`
+	MaxConcurrentQueries                      types.Int64                      `tfsdk:"max_concurrent_queries"`
+	KeepAliveTimeout                          types.String                     `tfsdk:"keep_alive_timeout"`
+	UncompressedCacheSize                     types.Int64                      `tfsdk:"uncompressed_cache_size"`
+	MarkCacheSize                             types.Int64                      `tfsdk:"mark_cache_size"`
+	MaxTableSizeToDrop                        types.Int64                      `tfsdk:"max_table_size_to_drop"`
+	MaxPartitionSizeToDrop                    types.Int64                      `tfsdk:"max_partition_size_to_drop"`
+	Timezone                                  types.String                     `tfsdk:"timezone"`
+	BackgroundPoolSize                        types.Int64                      `tfsdk:"background_pool_size"`
+	BackgroundSchedulePoolSize                types.Int64                      `tfsdk:"background_schedule_pool_size"`
+	BackgroundFetchesPoolSize                 types.Int64                      `tfsdk:"background_fetches_pool_size"`
+	BackgroundMovePoolSize                    types.Int64                      `tfsdk:"background_move_pool_size"`
+	BackgroundCommonPoolSize                  types.Int64                      `tfsdk:"background_common_pool_size"`
+	BackgroundMergesMutationsConcurrencyRatio types.Int64                      `tfsdk:"background_merges_mutations_concurrency_ratio"`
+	TotalMemoryProfilerStep                   types.Int64                      `tfsdk:"total_memory_profiler_step"`
+	TotalMemoryTrackerSampleProbability       types.Float64                    `tfsdk:"total_memory_tracker_sample_probability"`
+	BackgroundMessageBrokerSchedulePoolSize   types.Int64                      `tfsdk:"background_message_broker_schedule_pool_size"`
+	MergeTree                                 *clickhouseConfigMergeTree       `tfsdk:"merge_tree"`
+	Compression                               []clickhouseConfigCompression    `tfsdk:"compression"`
+	Kafka                                     *clickhouseConfigKafka           `tfsdk:"kafka"`
+	KafkaTopics                               map[string]clickhouseConfigKafka `tfsdk:"kafka_topic"`
+	Rabbitmq                                  *clickhouseConfigRabbitmq        `tfsdk:"rabbitmq"`
+	QueryLogRetentionSize                     types.Int64                      `tfsdk:"query_log_retention_size"`
+	QueryLogRetentionTime                     types.String                     `tfsdk:"query_log_retention_time"`
+	QueryThreadLogEnabled                     types.Bool                       `tfsdk:"query_thread_log_enabled"`
+	QueryThreadLogRetentionSize               types.Int64                      `tfsdk:"query_thread_log_retention_size"`
+	QueryThreadLogRetentionTime               types.String                     `tfsdk:"query_thread_log_retention_time"`
+	QueryViewsLogEnabled                      types.Bool                       `tfsdk:"query_views_log_enabled"`
+	QueryViewsLogRetentionSize                types.Int64                      `tfsdk:"query_views_log_retention_size"`
+	QueryViewsLogRetentionTime                types.String                     `tfsdk:"query_views_log_retention_time"`
+	PartLogRetentionSize                      types.Int64                      `tfsdk:"part_log_retention_size"`
+	PartLogRetentionTime                      types.String                     `tfsdk:"part_log_retention_time"`
+	MetricLogEnabled                          types.Bool                       `tfsdk:"metric_log_enabled"`
+	MetricLogRetentionSize                    types.Int64                      `tfsdk:"metric_log_retention_size"`
+	MetricLogRetentionTime                    types.String                     `tfsdk:"metric_log_retention_time"`
+	AsynchronousMetricLogEnabled              types.Bool                       `tfsdk:"asynchronous_metric_log_enabled"`
+	AsynchronousMetricLogRetentionSize        types.Int64                      `tfsdk:"asynchronous_metric_log_retention_size"`
+	AsynchronousMetricLogRetentionTime        types.String                     `tfsdk:"asynchronous_metric_log_retention_time"`
+	TraceLogEnabled                           types.Bool                       `tfsdk:"trace_log_enabled"`
+	TraceLogRetentionSize                     types.Int64                      `tfsdk:"trace_log_retention_size"`
+	TraceLogRetentionTime                     types.String                     `tfsdk:"trace_log_retention_time"`
+	TextLogEnabled                            types.Bool                       `tfsdk:"text_log_enabled"`
+	TextLogRetentionSize                      types.Int64                      `tfsdk:"text_log_retention_size"`
+	TextLogRetentionTime                      types.String                     `tfsdk:"text_log_retention_time"`
+	TextLogLevel                              types.String                     `tfsdk:"text_log_level"`
+	OpentelemetrySpanLogEnabled               types.Bool                       `tfsdk:"opentelemetry_span_log_enabled"`
+	OpentelemetrySpanLogRetentionSize         types.Int64                      `tfsdk:"opentelemetry_span_log_retention_size"`
+	OpentelemetrySpanLogRetentionTime         types.String                     `tfsdk:"opentelemetry_span_log_retention_time"`
+	SessionLogEnabled                         types.Bool                       `tfsdk:"session_log_enabled"`
+	SessionLogRetentionSize                   types.Int64                      `tfsdk:"session_log_retention_size"`
+	SessionLogRetentionTime                   types.String                     `tfsdk:"session_log_retention_time"`
+	ZookeeperLogEnabled                       types.Bool                       `tfsdk:"zookeeper_log_enabled"`
+	ZookeeperLogRetentionSize                 types.Int64                      `tfsdk:"zookeeper_log_retention_size"`
+	ZookeeperLogRetentionTime                 types.String                     `tfsdk:"zookeeper_log_retention_time"`
+	AsynchronousInsertLogEnabled              types.Bool                       `tfsdk:"asynchronous_insert_log_enabled"`
+	AsynchronousInsertLogRetentionSize        types.Int64                      `tfsdk:"asynchronous_insert_log_retention_size"`
+	AsynchronousInsertLogRetentionTime        types.String                     `tfsdk:"asynchronous_insert_log_retention_time"`
 	//     map<string,GraphiteRollup> graphite_rollup = 19;
 }
 
@@ -231,17 +260,62 @@ func clickhouseConfigCompressionMethodValidator() validator.String {
 	return stringvalidator.OneOfCaseInsensitive(names...)
 }
 
+// clickhouseCompressionLevels are levels supported by compression methods,
+// methods missing here have no levels.
+var clickhouseCompressionLevels = map[clickhouse.ClickhouseConfig_Compression_Method][2]int64{
+	clickhouse.ClickhouseConfig_Compression_METHOD_ZSTD:  {1, 22},
+	clickhouse.ClickhouseConfig_Compression_METHOD_LZ4HC: {1, 12},
+}
+
+// clickhouseCompressionLevel checks level of a compression rule against its method.
+type clickhouseCompressionLevel struct{}
+
+func (v clickhouseCompressionLevel) Description(ctx context.Context) string {
+	return "level must be within the range of the method: METHOD_ZSTD 1-22, METHOD_LZ4HC 1-12, METHOD_LZ4 has no levels"
+}
+
+func (v clickhouseCompressionLevel) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v clickhouseCompressionLevel) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	method, ok := req.ConfigValue.Attributes()["method"].(types.String)
+	if !ok || method.IsNull() || method.IsUnknown() {
+		return
+	}
+	level, ok := req.ConfigValue.Attributes()["level"].(types.Int64)
+	if !ok || level.IsNull() || level.IsUnknown() {
+		return
+	}
+
+	name := strings.ToUpper(method.ValueString())
+	levels, ok := clickhouseCompressionLevels[clickhouse.ClickhouseConfig_Compression_Method(clickhouse.ClickhouseConfig_Compression_Method_value[name])]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("level"), "invalid compression level", fmt.Sprintf("%s has no compression levels, remove level", name))
+		return
+	}
+	if l := level.ValueInt64(); l < levels[0] || l > levels[1] {
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("level"), "invalid compression level",
+			fmt.Sprintf("level of %s must be between %d and %d, got %d", name, levels[0], levels[1], l))
+	}
+}
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TransferEndpointResource{}
 var _ resource.ResourceWithImportState = &TransferEndpointResource{}
+var _ resource.ResourceWithModifyPlan = &ClickhouseClusterResource{}
 
 func NewClickhouseClusterResource() resource.Resource {
 	return &ClickhouseClusterResource{}
 }
 
 type ClickhouseClusterResource struct {
-	sdk *dcsdk.SDK
-	svc *dcgen.ClusterServiceClient
+	config *Config
+	sdk    *dcsdk.SDK
+	svc    *dcgen.ClusterServiceClient
 }
 
 func (r *ClickhouseClusterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -261,9 +335,9 @@ func (r *ClickhouseClusterResource) Schema(ctx context.Context, req resource.Sch
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"project_id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "ID of the project that the ClickHouse cluster belongs to.",
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 			"cloud_type": schema.StringAttribute{
 				Required:            true,
@@ -297,6 +371,20 @@ func (r *ClickhouseClusterResource) Schema(ctx context.Context, req resource.Sch
 				MarkdownDescription: "ID of the network that the ClickHouse cluster belongs to.",
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
+			"connection_info": schema.SingleNestedAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Public connection info of the ClickHouse cluster.",
+				Attributes:          clickhouseConnectionInfoResourceSchema(),
+				PlanModifiers:       []planmodifier.Object{objectplanmodifier.UseStateForUnknown()},
+			},
+			"private_connection_info": schema.SingleNestedAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Private connection info of the ClickHouse cluster, available from peered networks.",
+				Attributes:          clickhouseConnectionInfoResourceSchema(),
+				PlanModifiers:       []planmodifier.Object{objectplanmodifier.UseStateForUnknown()},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"resources": schema.SingleNestedBlock{
@@ -327,7 +415,7 @@ func (r *ClickhouseClusterResource) Schema(ctx context.Context, req resource.Sch
 					},
 				},
 			},
-			// "access"
+			"access": accessSchemaBlock(),
 			// Hide encryption due to deprecation
 			// "encryption": schema.SingleNestedBlock{
 			// 	Description: "Encryption configuration",
@@ -339,8 +427,50 @@ func (r *ClickhouseClusterResource) Schema(ctx context.Context, req resource.Sch
 			// 		},
 			// 	},
 			// },
-			"config": clickhouseConfigSchemaBlock(),
-			// maintenance window
+			"config":             clickhouseConfigSchemaBlock(),
+			"maintenance_window": maintenanceWindowSchemaBlock(),
+			"timeouts":           timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+func clickhouseConnectionInfoResourceSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"host": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Host to connect.",
+		},
+		"user": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "ClickHouse user.",
+		},
+		"password": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Password for ClickHouse user.",
+		},
+		"https_port": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Port to connect using HTTPS protocol.",
+		},
+		"tcp_port_secure": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Port to connect using TCP/native protocol.",
+		},
+		"native_protocol": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Connection string for ClickHouse native protocol.",
+		},
+		"https_uri": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "URI to connect using HTTPS protocol.",
+		},
+		"jdbc_uri": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "URI to connect using JDBC protocol.",
+		},
+		"odbc_uri": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "URI to connect using ODBC protocol.",
 		},
 	}
 }
@@ -351,20 +481,63 @@ func (r *ClickhouseClusterResource) Configure(ctx context.Context, req resource.
 		return
 	}
 
-	sdk, ok := req.ProviderData.(*dcsdk.SDK)
+	config, ok := req.ProviderData.(*Config)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *dcsdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.sdk = sdk
+	r.config = config
+	r.sdk = config.sdk
 	r.svc = r.sdk.ClickHouse().Cluster()
 }
 
+func (r *ClickhouseClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanProjectId(ctx, r.config, req, resp)
+
+	// Nothing to check on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	checkClickhouseConfigCleared(ctx, req, resp, "compression", "compression rules")
+	checkClickhouseConfigCleared(ctx, req, resp, "kafka_topic", "Kafka topics")
+}
+
+// checkClickhouseConfigCleared rejects removal of every element of a config collection.
+// UpdateClusterRequest has no field mask, so the API takes an empty collection
+// for an unset one and keeps the current elements of the cluster.
+func checkClickhouseConfigCleared(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, name, what string) {
+	p := path.Root("config").AtName(name)
+	var prior, planned attr.Value
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &prior)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if planned.IsUnknown() || clickhouseConfigElements(planned) > 0 || clickhouseConfigElements(prior) == 0 {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(p, fmt.Sprintf("can't remove all %s", what),
+		fmt.Sprintf("The API keeps the current %s of the cluster when none are given, keep at least one or recreate the cluster", what))
+}
+
+func clickhouseConfigElements(v attr.Value) int {
+	if v == nil || v.IsNull() || v.IsUnknown() {
+		return 0
+	}
+	switch v := v.(type) {
+	case types.List:
+		return len(v.Elements())
+	case types.Map:
+		return len(v.Elements())
+	}
+	return 0
+}
+
 func createClickhouseClusterRequest(m *clickhouseClusterModel) (*clickhouse.CreateClusterRequest, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	rq := &clickhouse.CreateClusterRequest{}
@@ -383,7 +556,8 @@ func createClickhouseClusterRequest(m *clickhouseClusterModel) (*clickhouse.Crea
 	resources, d := m.Resources.convert()
 	diags.Append(d...)
 	rq.Resources = resources
-	// TODO: access
+	rq.Access, d = m.Access.convert()
+	diags.Append(d...)
 	// Hide encryption due to deprecation
 	// if m.Encryption != nil {
 	// 	rq.Encryption = m.Encryption.convert()
@@ -392,12 +566,15 @@ func createClickhouseClusterRequest(m *clickhouseClusterModel) (*clickhouse.Crea
 		rq.ClickhouseConfig, d = m.Config.convert()
 		diags.Append(d...)
 	}
-	// TODO: mw
+	rq.MaintenanceWindow, d = m.MaintenanceWindow.convert()
+	diags.Append(d...)
 
 	return rq, diags
 }
 
 func (r *ClickhouseClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.config.listings.invalidate("clickhouse")
+
 	var data *clickhouseClusterModel
 
 	// Read Terraform plan data into the model
@@ -407,6 +584,14 @@ func (r *ClickhouseClusterResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, clickhouseTimeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	rq, diag := createClickhouseClusterRequest(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -414,28 +599,39 @@ func (r *ClickhouseClusterResource) Create(ctx context.Context, req resource.Cre
 	}
 	dcOperation, err := r.svc.Create(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(dcOperation, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
 	}
-	err = op.Wait(ctx)
+	data.Id = types.StringValue(op.ResourceId())
+
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		if op.ResourceId() != "" {
+			saveInterruptedCreate(ctx, resp, op, &data, err)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
 	}
 
-	data.Id = types.StringValue(op.ResourceId())
+	resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update computed fields
 	{
 		response, err := r.svc.Get(ctx, &clickhouse.GetClusterRequest{ClusterId: data.Id.ValueString()})
 		if err != nil {
-			resp.Diagnostics.AddError("failed to get", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 			return
 		}
-		resp.Diagnostics.Append(data.parse(response)...)
+		resp.Diagnostics.Append(data.parse(ctx, response, false)...)
 	}
 
 	// Save data into Terraform state
@@ -452,12 +648,30 @@ func (r *ClickhouseClusterResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, clickhouseTimeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(pollPendingOperation(ctx, r.sdk.WrapOperation, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	response, err := r.svc.Get(ctx, &clickhouse.GetClusterRequest{ClusterId: data.Id.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get", err.Error())
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("clickhouse cluster %s not found, removing from state", data.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 		return
 	}
-	resp.Diagnostics.Append(data.parse(response)...)
+	resp.Diagnostics.Append(data.parse(ctx, response, false)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -475,19 +689,46 @@ func updateClickhouseCluster(m *clickhouseClusterModel) (*clickhouse.UpdateClust
 	diags.Append(d...)
 	rq.Resources = resources
 
+	access, d := m.Access.convert()
+	diags.Append(d...)
+	rq.Access = access
+
 	config, d := m.Config.convert()
 	diags.Append(d...)
 	rq.ClickhouseConfig = config
 
+	mw, d := m.MaintenanceWindow.convert()
+	diags.Append(d...)
+	rq.MaintenanceWindow = mw
+
 	return rq, diags
 }
 
 func (r *ClickhouseClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.config.listings.invalidate("clickhouse")
+
 	var data *clickhouseClusterModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, clickhouseTimeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(waitPendingOperation(ctx, r.sdk.WrapOperation, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	rq, diag := updateClickhouseCluster(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -495,17 +736,17 @@ func (r *ClickhouseClusterResource) Update(ctx context.Context, req resource.Upd
 	}
 	dcOperation, err := r.svc.Update(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(dcOperation, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
-	err = op.Wait(ctx)
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
 
@@ -514,6 +755,8 @@ func (r *ClickhouseClusterResource) Update(ctx context.Context, req resource.Upd
 }
 
 func (r *ClickhouseClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.config.listings.invalidate("clickhouse")
+
 	var data *clickhouseClusterModel
 
 	// Read Terraform prior state data into the model
@@ -523,23 +766,54 @@ func (r *ClickhouseClusterResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, clickhouseTimeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(waitPendingOperation(ctx, r.sdk.WrapOperation, req.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	dcOperation, err := r.svc.Delete(ctx, &clickhouse.DeleteClusterRequest{ClusterId: data.Id.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(dcOperation, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+		return
+	}
+	err = waitOperation(ctx, op)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
 	}
-	err = op.Wait(ctx)
 }
 
+// ImportState fills in optional blocks the cluster has settings for,
+// Read keeps only configured blocks and settings afterwards.
 func (r *ClickhouseClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	response, err := r.svc.Get(ctx, &clickhouse.GetClusterRequest{ClusterId: req.ID})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
+		return
+	}
+	data := clickhouseClusterModel{Id: types.StringValue(req.ID)}
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
+	resp.Diagnostics.Append(data.parse(ctx, response, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (m *clickhouseClusterModel) parse(rs *clickhouse.Cluster) diag.Diagnostics {
+// parse refreshes the model from the cluster, imported fills in optional blocks.
+func (m *clickhouseClusterModel) parse(ctx context.Context, rs *clickhouse.Cluster, imported bool) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	m.ProjectId = types.StringValue(rs.ProjectId)
@@ -550,16 +824,36 @@ func (m *clickhouseClusterModel) parse(rs *clickhouse.Cluster) diag.Diagnostics
 	m.Version = types.StringValue(rs.Version)
 	m.NetworkId = types.StringValue(rs.NetworkId)
 
+	// blocks are missing on import
+	if m.Resources == nil {
+		m.Resources = &clickhouseClusterResources{}
+	}
 	diags.Append(m.Resources.parse(rs.Resources)...)
-	diags.Append(m.Config.parse(rs.ClickhouseConfig)...)
-	// parse access
+	if m.Config == nil {
+		m.Config = &clickhouseConfig{}
+	}
+	diags.Append(m.Config.parse(rs.ClickhouseConfig, imported)...)
+
+	var d diag.Diagnostics
+	m.ConnectionInfo, d = parseClickhouseConnectionInfoObject(ctx, parseClickhouseConnectionInfo(rs.ConnectionInfo))
+	diags.Append(d...)
+	m.PrivateConnectionInfo, d = parseClickhouseConnectionInfoObject(ctx, parseClickhousePrivateConnectionInfo(rs.PrivateConnectionInfo))
+	diags.Append(d...)
+	m.Access = parseAccess(m.Access, rs.Access, imported)
 
 	// Hide encryption due to deprecation
 	// m.Encryption.parse(rs.Encryption)
-	// parse MW
+	m.MaintenanceWindow = parseMaintenanceWindow(m.MaintenanceWindow, rs.MaintenanceWindow)
 	return diags
 }
 
+func parseClickhouseConnectionInfoObject(ctx context.Context, c *ClickhoiseConnectionInfo) (types.Object, diag.Diagnostics) {
+	if c == nil {
+		return types.ObjectNull(clickhouseConnectionInfoType.AttrTypes), nil
+	}
+	return types.ObjectValueFrom(ctx, clickhouseConnectionInfoType.AttrTypes, c)
+}
+
 func (m *clickhouseClusterResources) parse(rs *clickhouse.ClusterResources) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -637,12 +931,31 @@ func (m *clickhouseConfig) convert() (*clickhouse.ClickhouseConfig, diag.Diagnos
 	if v := m.BackgroundMessageBrokerSchedulePoolSize; !v.IsUnknown() && v.ValueInt64() != 0 {
 		config.BackgroundMessageBrokerSchedulePoolSize = wrapperspb.Int64(v.ValueInt64())
 	}
-	// merge_tree
-	// compression
+	if m.MergeTree != nil {
+		mergeTree, d := m.MergeTree.convert()
+		diags.Append(d...)
+		config.MergeTree = mergeTree
+	}
+	for _, c := range m.Compression {
+		config.Compression = append(config.Compression, c.convert())
+	}
 	// graphite_rollup
-	// kafka
-	// kafka_topics
-	// rabbitmq
+	if m.Kafka != nil {
+		kafka, d := m.Kafka.convert()
+		diags.Append(d...)
+		config.Kafka = kafka
+	}
+	for name, topic := range m.KafkaTopics {
+		kafka, d := topic.convert()
+		diags.Append(d...)
+		if config.KafkaTopics == nil {
+			config.KafkaTopics = map[string]*clickhouse.ClickhouseConfig_Kafka{}
+		}
+		config.KafkaTopics[name] = kafka
+	}
+	if m.Rabbitmq != nil {
+		config.Rabbitmq = m.Rabbitmq.convert()
+	}
 	if v := m.QueryLogRetentionSize; !v.IsUnknown() && v.ValueInt64() != 0 {
 		config.QueryLogRetentionSize = wrapperspb.Int64(v.ValueInt64())
 	}
@@ -812,7 +1125,7 @@ func (m *clickhouseConfig) convert() (*clickhouse.ClickhouseConfig, diag.Diagnos
 	return config, diags
 }
 
-func (m *clickhouseConfig) parse(rs *clickhouse.ClickhouseConfig) diag.Diagnostics {
+func (m *clickhouseConfig) parse(rs *clickhouse.ClickhouseConfig, imported bool) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	m.LogLevel = types.StringValue(rs.LogLevel.String())
@@ -870,12 +1183,12 @@ func (m *clickhouseConfig) parse(rs *clickhouse.ClickhouseConfig) diag.Diagnosti
 	if v := rs.BackgroundMessageBrokerSchedulePoolSize; v != nil {
 		m.BackgroundMessageBrokerSchedulePoolSize = types.Int64Value(v.Value)
 	}
-	// merge_tree
-	// compression
+	m.MergeTree = parseClickhouseConfigMergeTree(m.MergeTree, rs.MergeTree, imported)
+	m.Compression = parseClickhouseConfigCompression(m.Compression, rs.Compression)
 	// graphite_rollup
-	// kafka
-	// kafka topics
-	// rabbit_mq
+	m.Kafka = parseClickhouseConfigKafka(m.Kafka, rs.Kafka, imported)
+	m.KafkaTopics = parseClickhouseConfigKafkaTopics(m.KafkaTopics, rs.KafkaTopics, imported)
+	m.Rabbitmq = parseClickhouseConfigRabbitmq(m.Rabbitmq, rs.Rabbitmq, imported)
 	if v := rs.QueryLogRetentionSize; v != nil {
 		m.QueryLogRetentionSize = types.Int64Value(v.Value)
 	}
@@ -994,6 +1307,431 @@ func (m *clickhouseConfig) parse(rs *clickhouse.ClickhouseConfig) diag.Diagnosti
 	return diags
 }
 
+func (m *clickhouseConfigMergeTree) convert() (*clickhouse.ClickhouseConfig_MergeTree, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	// unset settings are omitted, so the cluster keeps their current values
+	return &clickhouse.ClickhouseConfig_MergeTree{
+		ReplicatedDeduplicationWindow:                  convertInt64Setting(m.ReplicatedDeduplicationWindow),
+		ReplicatedDeduplicationWindowSeconds:           convertDurationSetting("replicated_deduplication_window_seconds", m.ReplicatedDeduplicationWindowSeconds, &diags),
+		PartsToDelayInsert:                             convertInt64Setting(m.PartsToDelayInsert),
+		PartsToThrowInsert:                             convertInt64Setting(m.PartsToThrowInsert),
+		InactivePartsToDelayInsert:                     convertInt64Setting(m.InactivePartsToDelayInsert),
+		InactivePartsToThrowInsert:                     convertInt64Setting(m.InactivePartsToThrowInsert),
+		MaxReplicatedMergesInQueue:                     convertInt64Setting(m.MaxReplicatedMergesInQueue),
+		NumberOfFreeEntriesInPoolToLowerMaxSizeOfMerge: convertInt64Setting(m.NumberOfFreeEntriesInPoolToLowerMaxSizeOfMerge),
+		MaxBytesToMergeAtMinSpaceInPool:                convertInt64Setting(m.MaxBytesToMergeAtMinSpaceInPool),
+		MaxBytesToMergeAtMaxSpaceInPool:                convertInt64Setting(m.MaxBytesToMergeAtMaxSpaceInPool),
+		MinBytesForWidePart:                            convertInt64Setting(m.MinBytesForWidePart),
+		MinRowsForWidePart:                             convertInt64Setting(m.MinRowsForWidePart),
+		TtlOnlyDropParts:                               convertBoolSetting(m.TtlOnlyDropParts),
+		AllowRemoteFsZeroCopyReplication:               convertBoolSetting(m.AllowRemoteFsZeroCopyReplication),
+		MergeWithTtlTimeout:                            convertDurationSetting("merge_with_ttl_timeout", m.MergeWithTtlTimeout, &diags),
+		MergeWithRecompressionTtlTimeout:               convertDurationSetting("merge_with_recompression_ttl_timeout", m.MergeWithRecompressionTtlTimeout, &diags),
+		MaxPartsInTotal:                                convertInt64Setting(m.MaxPartsInTotal),
+		MaxNumberOfMergesWithTtlInPool:                 convertInt64Setting(m.MaxNumberOfMergesWithTtlInPool),
+		CleanupDelayPeriod:                             convertDurationSetting("cleanup_delay_period", m.CleanupDelayPeriod, &diags),
+		NumberOfFreeEntriesInPoolToExecuteMutation:     convertInt64Setting(m.NumberOfFreeEntriesInPoolToExecuteMutation),
+		MaxAvgPartSizeForTooManyParts:                  convertInt64Setting(m.MaxAvgPartSizeForTooManyParts),
+		MinAgeToForceMergeSeconds:                      convertDurationSetting("min_age_to_force_merge_seconds", m.MinAgeToForceMergeSeconds, &diags),
+		MinAgeToForceMergeOnPartitionOnly:              convertBoolSetting(m.MinAgeToForceMergeOnPartitionOnly),
+		MergeSelectingSleepMs:                          convertDurationSetting("merge_selecting_sleep_ms", m.MergeSelectingSleepMs, &diags),
+	}, diags
+}
+
+// parseClickhouseConfigMergeTree refreshes configured settings to detect drift.
+// Settings and the block absent from the configuration are left unset, unless
+// the cluster is being imported, since the cluster keeps values omitted from updates.
+func parseClickhouseConfigMergeTree(m *clickhouseConfigMergeTree, rs *clickhouse.ClickhouseConfig_MergeTree, imported bool) *clickhouseConfigMergeTree {
+	if m == nil {
+		if !imported || proto.Size(rs) == 0 {
+			return nil
+		}
+		m = &clickhouseConfigMergeTree{}
+	}
+
+	m.ReplicatedDeduplicationWindow = parseInt64Setting(m.ReplicatedDeduplicationWindow, rs.GetReplicatedDeduplicationWindow(), imported)
+	m.ReplicatedDeduplicationWindowSeconds = parseDurationSetting(m.ReplicatedDeduplicationWindowSeconds, rs.GetReplicatedDeduplicationWindowSeconds(), imported)
+	m.PartsToDelayInsert = parseInt64Setting(m.PartsToDelayInsert, rs.GetPartsToDelayInsert(), imported)
+	m.PartsToThrowInsert = parseInt64Setting(m.PartsToThrowInsert, rs.GetPartsToThrowInsert(), imported)
+	m.InactivePartsToDelayInsert = parseInt64Setting(m.InactivePartsToDelayInsert, rs.GetInactivePartsToDelayInsert(), imported)
+	m.InactivePartsToThrowInsert = parseInt64Setting(m.InactivePartsToThrowInsert, rs.GetInactivePartsToThrowInsert(), imported)
+	m.MaxReplicatedMergesInQueue = parseInt64Setting(m.MaxReplicatedMergesInQueue, rs.GetMaxReplicatedMergesInQueue(), imported)
+	m.NumberOfFreeEntriesInPoolToLowerMaxSizeOfMerge = parseInt64Setting(m.NumberOfFreeEntriesInPoolToLowerMaxSizeOfMerge, rs.GetNumberOfFreeEntriesInPoolToLowerMaxSizeOfMerge(), imported)
+	m.MaxBytesToMergeAtMinSpaceInPool = parseInt64Setting(m.MaxBytesToMergeAtMinSpaceInPool, rs.GetMaxBytesToMergeAtMinSpaceInPool(), imported)
+	m.MaxBytesToMergeAtMaxSpaceInPool = parseInt64Setting(m.MaxBytesToMergeAtMaxSpaceInPool, rs.GetMaxBytesToMergeAtMaxSpaceInPool(), imported)
+	m.MinBytesForWidePart = parseInt64Setting(m.MinBytesForWidePart, rs.GetMinBytesForWidePart(), imported)
+	m.MinRowsForWidePart = parseInt64Setting(m.MinRowsForWidePart, rs.GetMinRowsForWidePart(), imported)
+	m.TtlOnlyDropParts = parseBoolSetting(m.TtlOnlyDropParts, rs.GetTtlOnlyDropParts(), imported)
+	m.AllowRemoteFsZeroCopyReplication = parseBoolSetting(m.AllowRemoteFsZeroCopyReplication, rs.GetAllowRemoteFsZeroCopyReplication(), imported)
+	m.MergeWithTtlTimeout = parseDurationSetting(m.MergeWithTtlTimeout, rs.GetMergeWithTtlTimeout(), imported)
+	m.MergeWithRecompressionTtlTimeout = parseDurationSetting(m.MergeWithRecompressionTtlTimeout, rs.GetMergeWithRecompressionTtlTimeout(), imported)
+	m.MaxPartsInTotal = parseInt64Setting(m.MaxPartsInTotal, rs.GetMaxPartsInTotal(), imported)
+	m.MaxNumberOfMergesWithTtlInPool = parseInt64Setting(m.MaxNumberOfMergesWithTtlInPool, rs.GetMaxNumberOfMergesWithTtlInPool(), imported)
+	m.CleanupDelayPeriod = parseDurationSetting(m.CleanupDelayPeriod, rs.GetCleanupDelayPeriod(), imported)
+	m.NumberOfFreeEntriesInPoolToExecuteMutation = parseInt64Setting(m.NumberOfFreeEntriesInPoolToExecuteMutation, rs.GetNumberOfFreeEntriesInPoolToExecuteMutation(), imported)
+	m.MaxAvgPartSizeForTooManyParts = parseInt64Setting(m.MaxAvgPartSizeForTooManyParts, rs.GetMaxAvgPartSizeForTooManyParts(), imported)
+	m.MinAgeToForceMergeSeconds = parseDurationSetting(m.MinAgeToForceMergeSeconds, rs.GetMinAgeToForceMergeSeconds(), imported)
+	m.MinAgeToForceMergeOnPartitionOnly = parseBoolSetting(m.MinAgeToForceMergeOnPartitionOnly, rs.GetMinAgeToForceMergeOnPartitionOnly(), imported)
+	m.MergeSelectingSleepMs = parseDurationSetting(m.MergeSelectingSleepMs, rs.GetMergeSelectingSleepMs(), imported)
+	return m
+}
+
+func convertInt64Setting(v types.Int64) *wrapperspb.Int64Value {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return wrapperspb.Int64(v.ValueInt64())
+}
+
+func convertBoolSetting(v types.Bool) *wrapperspb.BoolValue {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return wrapperspb.Bool(v.ValueBool())
+}
+
+func convertDurationSetting(name string, v types.String, diags *diag.Diagnostics) *durationpb.Duration {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	duration, err := time.ParseDuration(v.ValueString())
+	if err != nil {
+		diags.AddError("failed to parse "+name, err.Error())
+		return nil
+	}
+	return durationpb.New(duration)
+}
+
+func convertStringSetting(v types.String) *wrapperspb.StringValue {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return wrapperspb.String(v.ValueString())
+}
+
+func parseStringSetting(prior types.String, v *wrapperspb.StringValue, all bool) types.String {
+	if prior.IsNull() && !all {
+		return prior
+	}
+	if v == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(v.Value)
+}
+
+// parseEnumSetting keeps the configured spelling of the enum value, as it is
+// validated case insensitively.
+func parseEnumSetting(prior types.String, name string, set, all bool) types.String {
+	if prior.IsNull() && !all {
+		return prior
+	}
+	if !set {
+		return types.StringNull()
+	}
+	if strings.EqualFold(prior.ValueString(), name) {
+		return prior
+	}
+	return types.StringValue(name)
+}
+
+func parseInt64Setting(prior types.Int64, v *wrapperspb.Int64Value, all bool) types.Int64 {
+	if prior.IsNull() && !all {
+		return prior
+	}
+	if v == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(v.Value)
+}
+
+func parseBoolSetting(prior types.Bool, v *wrapperspb.BoolValue, all bool) types.Bool {
+	if prior.IsNull() && !all {
+		return prior
+	}
+	if v == nil {
+		return types.BoolNull()
+	}
+	return types.BoolValue(v.Value)
+}
+
+// parseDurationSetting keeps the configured spelling of equal durations, e.g. 60s for 1m0s.
+func parseDurationSetting(prior types.String, v *durationpb.Duration, all bool) types.String {
+	if prior.IsNull() && !all {
+		return prior
+	}
+	if v == nil {
+		return types.StringNull()
+	}
+	if d, err := time.ParseDuration(prior.ValueString()); err == nil && d == v.AsDuration() {
+		return prior
+	}
+	return types.StringValue(v.AsDuration().String())
+}
+
+func (m *clickhouseConfigCompression) convert() *clickhouse.ClickhouseConfig_Compression {
+	return &clickhouse.ClickhouseConfig_Compression{
+		Method:           clickhouse.ClickhouseConfig_Compression_Method(clickhouse.ClickhouseConfig_Compression_Method_value[strings.ToUpper(m.Method.ValueString())]),
+		MinPartSize:      m.MinPartSize.ValueInt64(),
+		MinPartSizeRatio: m.MinPartSizeRatio.ValueFloat64(),
+		Level:            convertInt64Setting(m.Level),
+	}
+}
+
+// parseClickhouseConfigCompression returns compression rules of the cluster in
+// their order, keeping configured spelling of methods and an empty list empty.
+func parseClickhouseConfigCompression(prior []clickhouseConfigCompression, rs []*clickhouse.ClickhouseConfig_Compression) []clickhouseConfigCompression {
+	if len(rs) == 0 && prior != nil {
+		return []clickhouseConfigCompression{}
+	}
+	var rules []clickhouseConfigCompression
+	for i, c := range rs {
+		rule := clickhouseConfigCompression{
+			Method:           types.StringValue(c.Method.String()),
+			MinPartSize:      types.Int64Value(c.MinPartSize),
+			MinPartSizeRatio: types.Float64Value(c.MinPartSizeRatio),
+			Level:            types.Int64Null(),
+		}
+		if i < len(prior) && strings.EqualFold(prior[i].Method.ValueString(), rule.Method.ValueString()) {
+			rule.Method = prior[i].Method
+		}
+		if c.Level != nil {
+			rule.Level = types.Int64Value(c.Level.Value)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func clickhouseConfigCompressionSchema() schema.Attribute {
+	return schema.ListNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Compression rules for MergeTree tables, the first rule matching a data part is applied. Rules can't all be removed once set",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"method": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Compression method (METHOD_LZ4, METHOD_LZ4HC, METHOD_ZSTD)",
+					Validators:          []validator.String{clickhouseConfigCompressionMethodValidator()},
+				},
+				"min_part_size": schema.Int64Attribute{
+					Optional:            true,
+					Computed:            true,
+					Default:             int64default.StaticInt64(0),
+					MarkdownDescription: "Minimum size of a data part in bytes to apply the rule",
+					Validators:          []validator.Int64{int64validator.AtLeast(0)},
+				},
+				"min_part_size_ratio": schema.Float64Attribute{
+					Optional:            true,
+					Computed:            true,
+					Default:             float64default.StaticFloat64(0),
+					MarkdownDescription: "Minimum ratio of a data part size to the table size to apply the rule",
+					Validators:          []validator.Float64{float64validator.Between(0, 1)},
+				},
+				"level": schema.Int64Attribute{
+					Optional:            true,
+					MarkdownDescription: "Compression level: 1-22 for METHOD_ZSTD, 1-12 for METHOD_LZ4HC, the method default if unset",
+				},
+			},
+			Validators: []validator.Object{clickhouseCompressionLevel{}},
+		},
+	}
+}
+
+func clickhouseConfigDurationAttribute(description string) schema.Attribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: description + ", e.g. `30s`",
+		Validators:          []validator.String{durationValidator{}},
+	}
+}
+
+func clickhouseConfigMergeTreeSchemaBlock() schema.Block {
+	duration := clickhouseConfigDurationAttribute
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "MergeTree engine settings. Settings removed from the block keep their current values in the cluster",
+		Attributes: map[string]schema.Attribute{
+			"replicated_deduplication_window":                           schema.Int64Attribute{Optional: true},
+			"replicated_deduplication_window_seconds":                   duration("Time window for deduplication of inserted blocks"),
+			"parts_to_delay_insert":                                     schema.Int64Attribute{Optional: true},
+			"parts_to_throw_insert":                                     schema.Int64Attribute{Optional: true},
+			"inactive_parts_to_delay_insert":                            schema.Int64Attribute{Optional: true},
+			"inactive_parts_to_throw_insert":                            schema.Int64Attribute{Optional: true},
+			"max_replicated_merges_in_queue":                            schema.Int64Attribute{Optional: true},
+			"number_of_free_entries_in_pool_to_lower_max_size_of_merge": schema.Int64Attribute{Optional: true},
+			"max_bytes_to_merge_at_min_space_in_pool":                   schema.Int64Attribute{Optional: true},
+			"max_bytes_to_merge_at_max_space_in_pool":                   schema.Int64Attribute{Optional: true},
+			"min_bytes_for_wide_part":                                   schema.Int64Attribute{Optional: true},
+			"min_rows_for_wide_part":                                    schema.Int64Attribute{Optional: true},
+			"ttl_only_drop_parts":                                       schema.BoolAttribute{Optional: true},
+			"allow_remote_fs_zero_copy_replication":                     schema.BoolAttribute{Optional: true},
+			"merge_with_ttl_timeout":                                    duration("Minimum delay before repeating a merge with delete TTL"),
+			"merge_with_recompression_ttl_timeout":                      duration("Minimum delay before repeating a merge with recompression TTL"),
+			"max_parts_in_total":                                        schema.Int64Attribute{Optional: true},
+			"max_number_of_merges_with_ttl_in_pool":                     schema.Int64Attribute{Optional: true},
+			"cleanup_delay_period":                                      duration("Period to clean old queue logs, blocks hashes and parts"),
+			"number_of_free_entries_in_pool_to_execute_mutation":        schema.Int64Attribute{Optional: true},
+			"max_avg_part_size_for_too_many_parts":                      schema.Int64Attribute{Optional: true},
+			"min_age_to_force_merge_seconds":                            duration("Minimum age of parts to merge them regardless of the pool"),
+			"min_age_to_force_merge_on_partition_only":                  schema.BoolAttribute{Optional: true},
+			"merge_selecting_sleep_ms":                                  duration("Sleep time between attempts to select parts to merge"),
+		},
+	}
+}
+
+func (m *clickhouseConfigKafka) convert() (*clickhouse.ClickhouseConfig_Kafka, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rs := &clickhouse.ClickhouseConfig_Kafka{
+		SaslUsername:                     convertStringSetting(m.SaslUsername),
+		SaslPassword:                     convertStringSetting(m.SaslPassword),
+		EnableSslCertificateVerification: convertBoolSetting(m.EnableSslCertificateVerification),
+		MaxPollIntervalMs:                convertDurationSetting("max_poll_interval_ms", m.MaxPoolIntervalMs, &diags),
+		SessionTimeoutMs:                 convertDurationSetting("session_timeout_ms", m.SessionTimeoutMs, &diags),
+	}
+	if v := m.SecurityProtocol; !v.IsNull() && !v.IsUnknown() {
+		rs.SecurityProtocol = clickhouse.ClickhouseConfig_Kafka_SecurityProtocol(clickhouse.ClickhouseConfig_Kafka_SecurityProtocol_value[strings.ToUpper(v.ValueString())])
+	}
+	if v := m.SaslMechanism; !v.IsNull() && !v.IsUnknown() {
+		rs.SaslMechanism = clickhouse.ClickhouseConfig_Kafka_SaslMechanism(clickhouse.ClickhouseConfig_Kafka_SaslMechanism_value[strings.ToUpper(v.ValueString())])
+	}
+	return rs, diags
+}
+
+// parseClickhouseConfigKafka refreshes Kafka settings like merge_tree ones.
+// The API doesn't return the password, so it is kept as configured.
+func parseClickhouseConfigKafka(m *clickhouseConfigKafka, rs *clickhouse.ClickhouseConfig_Kafka, imported bool) *clickhouseConfigKafka {
+	if m == nil {
+		if !imported || proto.Size(rs) == 0 {
+			return nil
+		}
+		m = &clickhouseConfigKafka{}
+	}
+
+	m.SecurityProtocol = parseEnumSetting(m.SecurityProtocol, rs.GetSecurityProtocol().String(), rs.GetSecurityProtocol() != 0, imported)
+	m.SaslMechanism = parseEnumSetting(m.SaslMechanism, rs.GetSaslMechanism().String(), rs.GetSaslMechanism() != 0, imported)
+	m.SaslUsername = parseStringSetting(m.SaslUsername, rs.GetSaslUsername(), imported)
+	m.EnableSslCertificateVerification = parseBoolSetting(m.EnableSslCertificateVerification, rs.GetEnableSslCertificateVerification(), imported)
+	m.MaxPoolIntervalMs = parseDurationSetting(m.MaxPoolIntervalMs, rs.GetMaxPollIntervalMs(), imported)
+	m.SessionTimeoutMs = parseDurationSetting(m.SessionTimeoutMs, rs.GetSessionTimeoutMs(), imported)
+	return m
+}
+
+// parseClickhouseConfigKafkaTopics returns settings of topics present in the
+// cluster, passwords are taken from settings of the same topic in state.
+// Topics are left unset when absent from the configuration, unless the cluster
+// is being imported; topics missing from state get all their settings.
+func parseClickhouseConfigKafkaTopics(prior map[string]clickhouseConfigKafka, rs map[string]*clickhouse.ClickhouseConfig_Kafka, imported bool) map[string]clickhouseConfigKafka {
+	if prior == nil && (!imported || len(rs) == 0) {
+		return nil
+	}
+	topics := make(map[string]clickhouseConfigKafka, len(rs))
+	for name, topic := range rs {
+		var m *clickhouseConfigKafka
+		v, ok := prior[name]
+		if ok {
+			m = &v
+		}
+		if m = parseClickhouseConfigKafka(m, topic, !ok); m == nil {
+			m = &clickhouseConfigKafka{}
+		}
+		topics[name] = *m
+	}
+	return topics
+}
+
+func (m *clickhouseConfigRabbitmq) convert() *clickhouse.ClickhouseConfig_Rabbitmq {
+	return &clickhouse.ClickhouseConfig_Rabbitmq{
+		Username: convertStringSetting(m.Username),
+		Password: convertStringSetting(m.Password),
+		Vhost:    convertStringSetting(m.Vhost),
+	}
+}
+
+// parseClickhouseConfigRabbitmq refreshes RabbitMQ settings like merge_tree ones.
+// The API doesn't return the password, so it is kept as configured.
+func parseClickhouseConfigRabbitmq(m *clickhouseConfigRabbitmq, rs *clickhouse.ClickhouseConfig_Rabbitmq, imported bool) *clickhouseConfigRabbitmq {
+	if m == nil {
+		if !imported || proto.Size(rs) == 0 {
+			return nil
+		}
+		m = &clickhouseConfigRabbitmq{}
+	}
+
+	m.Username = parseStringSetting(m.Username, rs.GetUsername(), imported)
+	m.Vhost = parseStringSetting(m.Vhost, rs.GetVhost(), imported)
+	return m
+}
+
+func clickhouseConfigKafkaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"security_protocol": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Protocol to connect to brokers (SECURITY_PROTOCOL_PLAINTEXT, SECURITY_PROTOCOL_SSL, SECURITY_PROTOCOL_SASL_PLAINTEXT, SECURITY_PROTOCOL_SASL_SSL)",
+			Validators:          []validator.String{clickhouseConfigKafkaSecurityProtocolValidator()},
+		},
+		"sasl_mechanism": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "SASL mechanism to authenticate (SASL_MECHANISM_GSSAPI, SASL_MECHANISM_PLAIN, SASL_MECHANISM_SCRAM_SHA_256, SASL_MECHANISM_SCRAM_SHA_512)",
+			Validators:          []validator.String{clickhouseConfigKafkaSaslMechanismValidator()},
+		},
+		"sasl_username": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "SASL user name",
+		},
+		"sasl_password": schema.StringAttribute{
+			Optional:            true,
+			Sensitive:           true,
+			MarkdownDescription: "SASL password. The API doesn't return it, so changes made outside of Terraform aren't detected",
+		},
+		"enable_ssl_certificate_verification": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Verify SSL certificates of brokers",
+		},
+		"max_poll_interval_ms": clickhouseConfigDurationAttribute("Maximum delay between polls of the consumer"),
+		"session_timeout_ms":   clickhouseConfigDurationAttribute("Timeout of the consumer session"),
+	}
+}
+
+func clickhouseConfigRabbitmqSchemaBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "RabbitMQ settings of the RabbitMQ table engine. Settings removed from the block keep their current values in the cluster",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "RabbitMQ user name",
+			},
+			"password": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "RabbitMQ password. The API doesn't return it, so changes made outside of Terraform aren't detected",
+			},
+			"vhost": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "RabbitMQ virtual host",
+			},
+		},
+	}
+}
+
+// durationValidator checks that the value is a Go duration string.
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a duration, e.g. 30s, 5m or 1h30m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid duration", v.Description(ctx))
+	}
+}
+
 func clickhouseConfigSchemaBlock() schema.Block {
 	return schema.SingleNestedBlock{
 		Attributes: map[string]schema.Attribute{
@@ -1020,7 +1758,12 @@ func clickhouseConfigSchemaBlock() schema.Block {
 			"total_memory_profiler_step":                    schema.Int64Attribute{Optional: true},
 			"total_memory_tracker_sample_probability":       schema.Float64Attribute{Optional: true},
 			"background_message_broker_schedule_pool_size":  schema.Int64Attribute{Optional: true},
-			// merge_tree, compression, ...
+			"compression":                                   clickhouseConfigCompressionSchema(),
+			"kafka_topic": schema.MapNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Kafka settings of topics consumed by the Kafka table engine, keyed by topic name. They override `kafka` settings for the topic. Topics can't all be removed once set",
+				NestedObject:        schema.NestedAttributeObject{Attributes: clickhouseConfigKafkaAttributes()},
+			},
 			"query_log_retention_size": schema.Int64Attribute{Optional: true},
 			"query_log_retention_time": schema.StringAttribute{Optional: true},
 
@@ -1073,6 +1816,13 @@ func clickhouseConfigSchemaBlock() schema.Block {
 			"asynchronous_insert_log_retention_size": schema.Int64Attribute{Optional: true},
 			"asynchronous_insert_log_retention_time": schema.StringAttribute{Optional: true},
 		},
-		// Blocks
+		Blocks: map[string]schema.Block{
+			"merge_tree": clickhouseConfigMergeTreeSchemaBlock(),
+			"kafka": schema.SingleNestedBlock{
+				MarkdownDescription: "Kafka settings of the Kafka table engine. Settings removed from the block keep their current values in the cluster",
+				Attributes:          clickhouseConfigKafkaAttributes(),
+			},
+			"rabbitmq": clickhouseConfigRabbitmqSchemaBlock(),
+		},
 	}
 }
@@ -1,14 +1,22 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/doublecloud/go-genproto/doublecloud/clickhouse/v1"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
@@ -56,6 +64,28 @@ func TestAccClickhouseClusterResource(t *testing.T) {
 					resource.TestCheckResourceAttr(testAccClickhouseId, "resources.clickhouse.resource_preset_id", "s1-c2-m4"),
 					resource.TestCheckResourceAttr(testAccClickhouseId, "resources.clickhouse.disk_size", "34359738368"),
 					resource.TestCheckResourceAttr(testAccClickhouseId, "config.log_level", "LOG_LEVEL_INFORMATION"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "access.data_services.#", "1"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "access.ipv4_cidr_blocks.0.value", "203.0.113.0/24"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "access.ipv4_cidr_blocks.0.description", "office"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "maintenance_window.day", "saturday"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.merge_tree.parts_to_throw_insert", "600"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.compression.#", "1"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.compression.0.level", "3"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.compression.0.min_part_size_ratio", "0"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.kafka.sasl_mechanism", "SASL_MECHANISM_SCRAM_SHA_512"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.kafka.sasl_password", "kafka-secret"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.kafka_topic.events.sasl_username", "events-consumer"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.kafka_topic.events.sasl_password", "events-secret"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.rabbitmq.password", "rabbit-secret"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.merge_tree.ttl_only_drop_parts", "true"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.merge_tree.merge_with_ttl_timeout", "4h0m0s"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "maintenance_window.hour", "22"),
+					resource.TestCheckResourceAttrSet(testAccClickhouseId, "connection_info.host"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "connection_info.user", "admin"),
+					resource.TestCheckResourceAttrSet(testAccClickhouseId, "connection_info.password"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "connection_info.https_port", "8443"),
+					resource.TestCheckResourceAttrSet(testAccClickhouseId, "private_connection_info.host"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "private_connection_info.tcp_port_secure", "9440"),
 				),
 			},
 			// Update and Read testing
@@ -67,13 +97,319 @@ func TestAccClickhouseClusterResource(t *testing.T) {
 					resource.TestCheckResourceAttr(testAccClickhouseId, "resources.clickhouse.disk_size", "51539607552"),
 					resource.TestCheckResourceAttr(testAccClickhouseId, "config.log_level", "LOG_LEVEL_TRACE"),
 					resource.TestCheckResourceAttr(testAccClickhouseId, "config.max_connections", "120"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "access.data_services.#", "2"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "access.ipv4_cidr_blocks.#", "1"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "access.ipv4_cidr_blocks.0.value", "198.51.100.0/24"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "access.ipv6_cidr_blocks.0.value", "2001:db8::/32"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "maintenance_window.day", "sunday"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.merge_tree.parts_to_throw_insert", "900"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.compression.#", "2"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.compression.0.method", "METHOD_LZ4"),
+					resource.TestCheckNoResourceAttr(testAccClickhouseId, "config.compression.0.level"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.compression.1.method", "METHOD_ZSTD"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.compression.1.level", "9"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.kafka.sasl_password", "kafka-secret-changed"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.kafka.session_timeout_ms", "45s"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.kafka_topic.%", "2"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.kafka_topic.metrics.enable_ssl_certificate_verification", "false"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.rabbitmq.vhost", "analytics"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.merge_tree.ttl_only_drop_parts", "false"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.merge_tree.max_bytes_to_merge_at_max_space_in_pool", "53687091200"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.merge_tree.cleanup_delay_period", "30s"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "maintenance_window.hour", "2"),
+					resource.TestCheckResourceAttrSet(testAccClickhouseId, "connection_info.host"),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:      testAccClickhouseId,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the API doesn't return passwords of integrations
+				ImportStateVerifyIgnore: []string{
+					"config.kafka.sasl_password",
+					"config.kafka_topic.events.sasl_password",
+					"config.rabbitmq.password",
+				},
+			},
+			// Removing the access block keeps settings of the cluster
+			{
+				Config: removeConfigBlock(testAccClickhouseClusterResourceConfigUpdated(&m2), "access"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(testAccClickhouseId, "access.data_services.#"),
+					resource.TestCheckNoResourceAttr(testAccClickhouseId, "access.ipv4_cidr_blocks.#"),
+				),
+			},
+			{
+				Config:   removeConfigBlock(testAccClickhouseClusterResourceConfigUpdated(&m2), "access"),
+				PlanOnly: true,
+			},
+			// So does removing the merge_tree block
+			{
+				Config: removeConfigBlock(removeConfigBlock(testAccClickhouseClusterResourceConfigUpdated(&m2), "access"), "merge_tree"),
+				Check:  resource.TestCheckNoResourceAttr(testAccClickhouseId, "config.merge_tree.parts_to_throw_insert"),
+			},
+			{
+				Config:   removeConfigBlock(removeConfigBlock(testAccClickhouseClusterResourceConfigUpdated(&m2), "access"), "merge_tree"),
+				PlanOnly: true,
+			},
+			// So does removing kafka and rabbitmq blocks
+			{
+				Config: removeConfigBlock(removeConfigBlock(removeConfigBlock(removeConfigBlock(testAccClickhouseClusterResourceConfigUpdated(&m2), "access"), "merge_tree"), "kafka"), "rabbitmq"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(testAccClickhouseId, "config.kafka.sasl_username"),
+					resource.TestCheckNoResourceAttr(testAccClickhouseId, "config.rabbitmq.username"),
+					resource.TestCheckResourceAttr(testAccClickhouseId, "config.kafka_topic.%", "2"),
+				),
+			},
+			{
+				Config:   removeConfigBlock(removeConfigBlock(removeConfigBlock(removeConfigBlock(testAccClickhouseClusterResourceConfigUpdated(&m2), "access"), "merge_tree"), "kafka"), "rabbitmq"),
+				PlanOnly: true,
+			},
+			// The API can't remove every Kafka topic
+			{
+				Config:      removeConfigBlock(removeConfigBlock(removeConfigBlock(removeConfigBlock(removeConfigBlock(testAccClickhouseClusterResourceConfigUpdated(&m2), "access"), "merge_tree"), "kafka"), "rabbitmq"), "kafka_topic"),
+				ExpectError: regexp.MustCompile(`can't remove all Kafka topics`),
+			},
+			// Nor every compression rule
+			{
+				Config:      removeConfigBlock(removeConfigBlock(removeConfigBlock(testAccClickhouseClusterResourceConfigUpdated(&m2), "access"), "merge_tree"), "compression"),
+				ExpectError: regexp.MustCompile(`can't remove all compression rules`),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
 
+func TestAccClickhouseClusterResourceMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+	config := func(window string) string {
+		return fmt.Sprintf(`
+resource "doublecloud_clickhouse_cluster" "tf-acc-clickhouse-mw" {
+  project_id = %[1]q
+  name = "%[2]v-mw"
+  region_id = "eu-central-1"
+  cloud_type = "aws"
+  network_id = %[3]q
+
+  resources {
+	clickhouse {
+		resource_preset_id = "s1-c2-m4"
+		disk_size = 34359738368
+		replica_count = 1
+	}
+  }
+
+  config {
+	log_level = "LOG_LEVEL_INFORMATION"
+  }
+
+  maintenance_window {
+	%[4]v
+  }
+}
+`, testProjectId, testAccClickhouseName, testNetworkId, window)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config:      config("anytime = false"),
+				ExpectError: regexp.MustCompile(`value must be true`),
+			},
+			{
+				Config:      config(`anytime = true` + "\n" + `day = "saturday"` + "\n" + `hour = 22`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config:      config(`day = "saturday"`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config:      config(`day = "caturday"` + "\n" + `hour = 25`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value(.|\n)*Invalid Attribute Value`),
+			},
+			{
+				Config: config("anytime = true"),
+				Check:  resource.TestCheckResourceAttr("doublecloud_clickhouse_cluster.tf-acc-clickhouse-mw", "maintenance_window.anytime", "true"),
+			},
+		},
+	})
+}
+
+func TestClickhouseConfigMergeTreeParse(t *testing.T) {
+	rs := &clickhouse.ClickhouseConfig_MergeTree{
+		PartsToThrowInsert:  wrapperspb.Int64(600),
+		TtlOnlyDropParts:    wrapperspb.Bool(false),
+		MergeWithTtlTimeout: durationpb.New(time.Minute),
+		CleanupDelayPeriod:  durationpb.New(30 * time.Second),
+	}
+
+	m := parseClickhouseConfigMergeTree(&clickhouseConfigMergeTree{
+		PartsToThrowInsert:  types.Int64Value(300),
+		TtlOnlyDropParts:    types.BoolValue(false),
+		MergeWithTtlTimeout: types.StringValue("60s"),
+		MinBytesForWidePart: types.Int64Value(1024),
+	}, rs, false)
+	if m.PartsToThrowInsert.ValueInt64() != 600 {
+		t.Errorf("expected drifted value 600, got %v", m.PartsToThrowInsert)
+	}
+	if m.MergeWithTtlTimeout.ValueString() != "60s" {
+		t.Errorf("expected configured spelling of equal duration, got %v", m.MergeWithTtlTimeout)
+	}
+	if !m.MinBytesForWidePart.IsNull() {
+		t.Errorf("expected setting missing from cluster to become null, got %v", m.MinBytesForWidePart)
+	}
+	if !m.CleanupDelayPeriod.IsNull() {
+		t.Errorf("expected unconfigured setting to stay null, got %v", m.CleanupDelayPeriod)
+	}
+
+	if unconfigured := parseClickhouseConfigMergeTree(nil, rs, false); unconfigured != nil {
+		t.Errorf("expected unconfigured block to stay absent, got %+v", unconfigured)
+	}
+	imported := parseClickhouseConfigMergeTree(nil, rs, true)
+	if imported == nil || imported.CleanupDelayPeriod.ValueString() != "30s" || imported.MergeWithTtlTimeout.ValueString() != "1m0s" {
+		t.Errorf("expected every setting on import, got %+v", imported)
+	}
+	if parseClickhouseConfigMergeTree(nil, &clickhouse.ClickhouseConfig_MergeTree{}, true) != nil {
+		t.Errorf("expected empty block not to be imported")
+	}
+
+	rq, diags := m.convert()
+	if diags.HasError() || rq.GetMergeWithTtlTimeout().AsDuration() != time.Minute || rq.GetTtlOnlyDropParts() == nil || rq.GetCleanupDelayPeriod() != nil {
+		t.Errorf("unexpected request %v (%v)", rq, diags)
+	}
+}
+
+func TestClickhouseCompressionLevelValidator(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		method string
+		level  types.Int64
+		err    string
+	}{
+		{method: "METHOD_ZSTD", level: types.Int64Value(22)},
+		{method: "method_lz4hc", level: types.Int64Value(12)},
+		{method: "METHOD_LZ4", level: types.Int64Null()},
+		{method: "METHOD_ZSTD", level: types.Int64Value(23), err: "between 1 and 22"},
+		{method: "METHOD_LZ4HC", level: types.Int64Value(0), err: "between 1 and 12"},
+		{method: "METHOD_LZ4", level: types.Int64Value(1), err: "has no compression levels"},
+	} {
+		value := types.ObjectValueMust(
+			map[string]attr.Type{"method": types.StringType, "level": types.Int64Type},
+			map[string]attr.Value{"method": types.StringValue(tc.method), "level": tc.level},
+		)
+		rs := validator.ObjectResponse{}
+		clickhouseCompressionLevel{}.ValidateObject(ctx, validator.ObjectRequest{
+			Path:        path.Root("compression").AtListIndex(0),
+			ConfigValue: value,
+		}, &rs)
+		switch {
+		case tc.err == "" && rs.Diagnostics.HasError():
+			t.Errorf("%s %v: unexpected error %v", tc.method, tc.level, rs.Diagnostics)
+		case tc.err != "" && (rs.Diagnostics.ErrorsCount() != 1 || !strings.Contains(rs.Diagnostics[0].Detail(), tc.err)):
+			t.Errorf("%s %v: expected error %q, got %v", tc.method, tc.level, tc.err, rs.Diagnostics)
+		}
+	}
+}
+
+func TestClickhouseConfigCompressionParse(t *testing.T) {
+	rs := []*clickhouse.ClickhouseConfig_Compression{
+		{Method: clickhouse.ClickhouseConfig_Compression_METHOD_LZ4, MinPartSizeRatio: 0.01},
+		{Method: clickhouse.ClickhouseConfig_Compression_METHOD_ZSTD, Level: wrapperspb.Int64(9)},
+	}
+	rules := parseClickhouseConfigCompression([]clickhouseConfigCompression{
+		{Method: types.StringValue("method_lz4")},
+		{Method: types.StringValue("method_lz4hc")},
+	}, rs)
+	if len(rules) != 2 || rules[0].Method.ValueString() != "method_lz4" || rules[1].Method.ValueString() != "METHOD_ZSTD" {
+		t.Errorf("expected configured spelling of the same method only, got %v", rules)
+	}
+	if !rules[0].Level.IsNull() || rules[1].Level.ValueInt64() != 9 || rules[0].MinPartSizeRatio.ValueFloat64() != 0.01 {
+		t.Errorf("unexpected rules %v", rules)
+	}
+	if got := rules[1].convert(); got.Method != clickhouse.ClickhouseConfig_Compression_METHOD_ZSTD || got.Level.GetValue() != 9 {
+		t.Errorf("unexpected rule %v", got)
+	}
+
+	if rules := parseClickhouseConfigCompression([]clickhouseConfigCompression{}, nil); rules == nil || len(rules) != 0 {
+		t.Errorf("expected configured empty list to stay empty, got %v", rules)
+	}
+	if rules := parseClickhouseConfigCompression(nil, nil); rules != nil {
+		t.Errorf("expected unconfigured rules to stay absent, got %v", rules)
+	}
+}
+
+func TestClickhouseConfigKafkaTopicsParse(t *testing.T) {
+	prior := map[string]clickhouseConfigKafka{
+		"events": {
+			SecurityProtocol: types.StringValue("security_protocol_sasl_ssl"),
+			SaslUsername:     types.StringValue("consumer"),
+			SaslPassword:     types.StringValue("secret"),
+		},
+		"removed": {SaslUsername: types.StringValue("consumer")},
+	}
+	topics := parseClickhouseConfigKafkaTopics(prior, map[string]*clickhouse.ClickhouseConfig_Kafka{
+		"events": {
+			SecurityProtocol: clickhouse.ClickhouseConfig_Kafka_SECURITY_PROTOCOL_SASL_SSL,
+			SaslUsername:     wrapperspb.String("changed"),
+		},
+		"added": {SaslMechanism: clickhouse.ClickhouseConfig_Kafka_SASL_MECHANISM_PLAIN},
+	}, false)
+
+	events := topics["events"]
+	if events.SaslPassword.ValueString() != "secret" || events.SaslUsername.ValueString() != "changed" {
+		t.Errorf("expected kept password and drifted user name, got %+v", events)
+	}
+	if events.SecurityProtocol.ValueString() != "security_protocol_sasl_ssl" || !events.SaslMechanism.IsNull() {
+		t.Errorf("expected configured settings only, got %+v", events)
+	}
+	if _, ok := topics["removed"]; ok {
+		t.Errorf("expected topic missing from the cluster to be dropped")
+	}
+	if added := topics["added"]; added.SaslMechanism.ValueString() != "SASL_MECHANISM_PLAIN" || !added.SaslPassword.IsNull() {
+		t.Errorf("expected every setting of a new topic, got %+v", added)
+	}
+
+	rs := map[string]*clickhouse.ClickhouseConfig_Kafka{"events": {SaslUsername: wrapperspb.String("consumer")}}
+	if topics := parseClickhouseConfigKafkaTopics(nil, rs, false); topics != nil {
+		t.Errorf("expected unconfigured topics to stay absent, got %v", topics)
+	}
+	if topics := parseClickhouseConfigKafkaTopics(nil, rs, true); topics["events"].SaslUsername.ValueString() != "consumer" {
+		t.Errorf("expected imported topics, got %v", topics)
+	}
+	if topics := parseClickhouseConfigKafkaTopics(map[string]clickhouseConfigKafka{}, nil, false); topics == nil || len(topics) != 0 {
+		t.Errorf("expected configured empty map to stay empty, got %v", topics)
+	}
+}
+
+func TestClickhouseConfigIntegrationsParse(t *testing.T) {
+	kafka := &clickhouse.ClickhouseConfig_Kafka{SaslUsername: wrapperspb.String("consumer")}
+	if m := parseClickhouseConfigKafka(nil, kafka, false); m != nil {
+		t.Errorf("expected unconfigured kafka block to stay absent, got %+v", m)
+	}
+	if m := parseClickhouseConfigKafka(nil, kafka, true); m == nil || m.SaslUsername.ValueString() != "consumer" {
+		t.Errorf("expected imported kafka block, got %+v", m)
+	}
+
+	rabbitmq := &clickhouse.ClickhouseConfig_Rabbitmq{Username: wrapperspb.String("clickhouse")}
+	if m := parseClickhouseConfigRabbitmq(nil, rabbitmq, false); m != nil {
+		t.Errorf("expected unconfigured rabbitmq block to stay absent, got %+v", m)
+	}
+	if m := parseClickhouseConfigRabbitmq(nil, rabbitmq, true); m == nil || m.Username.ValueString() != "clickhouse" {
+		t.Errorf("expected imported rabbitmq block, got %+v", m)
+	}
+	if m := parseClickhouseConfigRabbitmq(nil, nil, true); m != nil {
+		t.Errorf("expected rabbitmq block without settings to stay absent, got %+v", m)
+	}
+}
+
 func testAccClickhouseClusterResourceConfig(m *clickhouseClusterModel) string {
 	return fmt.Sprintf(`
 resource "doublecloud_clickhouse_cluster" "tf-acc-clickhouse" {
@@ -93,6 +429,50 @@ resource "doublecloud_clickhouse_cluster" "tf-acc-clickhouse" {
 
   config {
 	log_level = "LOG_LEVEL_INFORMATION"
+
+	compression = [{
+		method = "METHOD_ZSTD"
+		level = 3
+		min_part_size = 1048576
+	}]
+
+	merge_tree {
+		parts_to_throw_insert = 600
+		ttl_only_drop_parts = true
+		merge_with_ttl_timeout = "4h0m0s"
+	}
+
+	kafka {
+		security_protocol = "SECURITY_PROTOCOL_SASL_SSL"
+		sasl_mechanism = "SASL_MECHANISM_SCRAM_SHA_512"
+		sasl_username = "consumer"
+		sasl_password = "kafka-secret"
+	}
+
+	kafka_topic = {
+		events = {
+			sasl_username = "events-consumer"
+			sasl_password = "events-secret"
+		}
+	}
+
+	rabbitmq {
+		username = "clickhouse"
+		password = "rabbit-secret"
+	}
+  }
+
+  access {
+	data_services = ["transfer"]
+	ipv4_cidr_blocks = [{
+		value = "203.0.113.0/24"
+		description = "office"
+	}]
+  }
+
+  maintenance_window {
+	day = "saturday"
+	hour = 22
   }
 }
 `, m.ProjectId.ValueString(),
@@ -126,6 +506,64 @@ resource "doublecloud_clickhouse_cluster" "tf-acc-clickhouse" {
   config {
 	log_level = "LOG_LEVEL_TRACE"
 	max_connections = 120
+
+	compression = [{
+		method = "METHOD_LZ4"
+		min_part_size_ratio = 0.01
+	}, {
+		method = "METHOD_ZSTD"
+		level = 9
+		min_part_size = 1073741824
+	}]
+
+	merge_tree {
+		parts_to_throw_insert = 900
+		ttl_only_drop_parts = false
+		max_bytes_to_merge_at_max_space_in_pool = 53687091200
+		merge_with_ttl_timeout = "2h0m0s"
+		cleanup_delay_period = "30s"
+	}
+
+	kafka {
+		security_protocol = "SECURITY_PROTOCOL_SASL_SSL"
+		sasl_mechanism = "SASL_MECHANISM_SCRAM_SHA_512"
+		sasl_username = "consumer"
+		sasl_password = "kafka-secret-changed"
+		session_timeout_ms = "45s"
+	}
+
+	kafka_topic = {
+		events = {
+			sasl_username = "events-consumer"
+			sasl_password = "events-secret"
+		}
+		metrics = {
+			security_protocol = "SECURITY_PROTOCOL_SSL"
+			enable_ssl_certificate_verification = false
+		}
+	}
+
+	rabbitmq {
+		username = "clickhouse"
+		password = "rabbit-secret"
+		vhost = "analytics"
+	}
+  }
+
+  access {
+	data_services = ["transfer", "visualization"]
+	ipv4_cidr_blocks = [{
+		value = "198.51.100.0/24"
+	}]
+	ipv6_cidr_blocks = [{
+		value = "2001:db8::/32"
+		description = "office"
+	}]
+  }
+
+  maintenance_window {
+	day = "sunday"
+	hour = 2
   }
 }
 `, m.ProjectId.ValueString(),
@@ -139,6 +577,21 @@ resource "doublecloud_clickhouse_cluster" "tf-acc-clickhouse" {
 	)
 }
 
+// removeConfigBlock removes the first block, or nested attribute, with the name from config.
+func removeConfigBlock(config, name string) string {
+	loc := regexp.MustCompile(`\n([ \t]*)` + name + ` (?:= )?(\[?)\{\n`).FindStringSubmatchIndex(config)
+	if loc == nil {
+		panic(fmt.Sprintf("block %s not found", name))
+	}
+	start, indent := loc[0], config[loc[2]:loc[3]]
+	closing := "\n" + indent + "}"
+	if loc[5] > loc[4] {
+		closing += "]"
+	}
+	end := loc[1] + strings.Index(config[loc[1]:], closing+"\n") + len(closing)
+	return config[:start] + config[end:]
+}
+
 func init() {
 	resource.AddTestSweepers("clickhouse", &resource.Sweeper{
 		Name:         "clickhouse",
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	dcsdk "github.com/doublecloud/go-sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClickhouseClustersDataSource{}
+
+func NewClickhouseClustersDataSource() datasource.DataSource {
+	return &ClickhouseClustersDataSource{}
+}
+
+type ClickhouseClustersDataSource struct {
+	config *Config
+	sdk    *dcsdk.SDK
+}
+
+func (d *ClickhouseClustersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clickhouse_clusters"
+}
+
+func (d *ClickhouseClustersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = clustersDataSourceSchema("ClickHouse")
+}
+
+func (d *ClickhouseClustersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*Config)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.config = config
+	d.sdk = config.sdk
+}
+
+func (d *ClickhouseClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data clustersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectId, diags := d.config.projectId(data.ProjectID)
+	resp.Diagnostics.Append(diags...)
+	nameRegex, diags := compileNameRegex(data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = projectId
+	data.ProjectID = projectId
+
+	data.Clusters = []clustersItemModel{}
+	items, err := d.config.clickhouseClusters(ctx, projectId.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to list", err)...)
+		return
+	}
+	for _, c := range items {
+		data.add(nameRegex, c.Id, c.Name, c.Description, c.RegionId, c.CloudType, c.Version, c.NetworkId, c.Status)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccClickhouseClustersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccClickhouseClustersDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.doublecloud_clickhouse_clusters.test", "clusters.#", "1"),
+					resource.TestCheckResourceAttr("data.doublecloud_clickhouse_clusters.test", "clusters.0.name", testClickhouseName),
+					resource.TestCheckResourceAttr("data.doublecloud_clickhouse_clusters.test", "clusters.0.region_id", "eu-central-1"),
+					resource.TestCheckResourceAttr("data.doublecloud_clickhouse_clusters.test", "clusters.0.cloud_type", "aws"),
+					resource.TestCheckResourceAttr("data.doublecloud_clickhouse_clusters.test", "clusters.0.status", "alive"),
+					resource.TestCheckResourceAttrSet("data.doublecloud_clickhouse_clusters.test", "clusters.0.network_id"),
+					resource.TestCheckResourceAttr("data.doublecloud_clickhouse_clusters.stopped", "clusters.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccClickhouseClustersDataSourceConfig() string {
+	return fmt.Sprintf(`
+data "doublecloud_clickhouse_clusters" "test" {
+	project_id = %[1]q
+	name_regex = "^%[2]s$"
+	cloud_type = "aws"
+	status = "alive"
+}
+
+data "doublecloud_clickhouse_clusters" "stopped" {
+	project_id = %[1]q
+	name_regex = "^%[2]s$"
+	status = "stopped"
+}`, testProjectId, testClickhouseName)
+}
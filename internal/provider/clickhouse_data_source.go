@@ -21,8 +21,9 @@ func NewClickhouseDataSource() datasource.DataSource {
 }
 
 type ClickhouseDataSource struct {
-	sdk *dcsdk.SDK
-	svc *dcgen.ClusterServiceClient
+	config *Config
+	sdk    *dcsdk.SDK
+	svc    *dcgen.ClusterServiceClient
 }
 
 type ClickhouseDataSourceModel struct {
@@ -99,7 +100,8 @@ func (d *ClickhouseDataSource) Schema(ctx context.Context, req datasource.Schema
 		MarkdownDescription: "Clickhouse data soruce",
 		Attributes: map[string]schema.Attribute{
 			"project_id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "Project identifier",
 			},
 			"id": schema.StringAttribute{
@@ -145,17 +147,18 @@ func (d *ClickhouseDataSource) Configure(ctx context.Context, req datasource.Con
 		return
 	}
 
-	sdk, ok := req.ProviderData.(*dcsdk.SDK)
+	config, ok := req.ProviderData.(*Config)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *dcsdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.sdk = sdk
+	d.config = config
+	d.sdk = config.sdk
 	d.svc = d.sdk.ClickHouse().Cluster()
 }
 
@@ -167,8 +170,8 @@ func parseClickhouseConnectionInfo(r *clickhouse.ConnectionInfo) *ClickhoiseConn
 	c.Host = types.StringValue(r.Host)
 	c.User = types.StringValue(r.User)
 	c.Password = types.StringValue(r.Password)
-	c.HttpsPort = types.Int64Value(r.HttpsPort.Value)
-	c.TcpPortSecure = types.Int64Value(r.TcpPortSecure.Value)
+	c.HttpsPort = types.Int64Value(r.GetHttpsPort().GetValue())
+	c.TcpPortSecure = types.Int64Value(r.GetTcpPortSecure().GetValue())
 	c.NativeProtocol = types.StringValue(r.NativeProtocol)
 	c.HttpsUri = types.StringValue(r.HttpsUri)
 	c.JdbcUri = types.StringValue(r.JdbcUri)
@@ -184,8 +187,8 @@ func parseClickhousePrivateConnectionInfo(r *clickhouse.PrivateConnectionInfo) *
 	c.Host = types.StringValue(r.Host)
 	c.User = types.StringValue(r.User)
 	c.Password = types.StringValue(r.Password)
-	c.HttpsPort = types.Int64Value(r.HttpsPort.Value)
-	c.TcpPortSecure = types.Int64Value(r.TcpPortSecure.Value)
+	c.HttpsPort = types.Int64Value(r.GetHttpsPort().GetValue())
+	c.TcpPortSecure = types.Int64Value(r.GetTcpPortSecure().GetValue())
 	c.NativeProtocol = types.StringValue(r.NativeProtocol)
 	c.HttpsUri = types.StringValue(r.HttpsUri)
 	c.JdbcUri = types.StringValue(r.JdbcUri)
@@ -203,29 +206,30 @@ func (d *ClickhouseDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	projectId, diags := d.config.projectId(data.ProjectID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ProjectID = projectId
+
 	if data.Id == types.StringNull() && data.Name == types.StringNull() {
 		resp.Diagnostics.AddError("missing attribute", "specify one of: id or name")
 		return
 	}
 
 	if data.Id == types.StringNull() {
-		it := d.svc.ClusterIterator(ctx, &clickhouse.ListClustersRequest{ProjectId: data.ProjectID.ValueString()})
-		for it.Next() {
-			c := it.Value()
-			if c.Name == data.Name.ValueString() {
-				data.Id = types.StringValue(c.Id)
-				break
-			}
-		}
-		if data.Id == types.StringNull() {
-			resp.Diagnostics.AddError("cluster not found", fmt.Sprintf("clickhouse cluster `%v` haven't found", data.Name.ValueString()))
+		id, diags := lookupByName(ctx, "clickhouse cluster", data.ProjectID.ValueString(), data.Name.ValueString(), d.config.clickhouseClusters)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+		data.Id = types.StringValue(id)
 	}
 
 	response, err := d.svc.Get(ctx, &clickhouse.GetClusterRequest{ClusterId: data.Id.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 		return
 	}
 
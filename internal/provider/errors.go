@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcStatus extracts gRPC status from err, looking through wrapped errors.
+func grpcStatus(err error) *status.Status {
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		return se.GRPCStatus()
+	}
+	return status.Convert(err)
+}
+
+// isNotFoundError reports whether API responded that the requested object doesn't exist.
+func isNotFoundError(err error) bool {
+	return err != nil && grpcStatus(err).Code() == codes.NotFound
+}
+
+var errorHints = map[codes.Code]string{
+	codes.InvalidArgument:    "Check the resource configuration.",
+	codes.NotFound:           "The object may have been deleted outside of Terraform, or the ID refers to another project.",
+	codes.AlreadyExists:      "An object with the same name already exists. Choose another name or import the existing object with terraform import.",
+	codes.PermissionDenied:   "Check that the service account used by the provider has access to the project and the required roles.",
+	codes.Unauthenticated:    "Check provider credentials: the authorized key may be revoked or the token may have expired.",
+	codes.ResourceExhausted:  "The project quota is exhausted. Remove unused resources or request a quota increase in the DoubleCloud console.",
+	codes.FailedPrecondition: "The object is not in a state that allows this change, for example another operation is still running. Retry after it finishes.",
+	codes.Unavailable:        "DoubleCloud API is temporarily unavailable, retry later.",
+	codes.DeadlineExceeded:   "The request took too long, retry later or increase timeouts.",
+}
+
+// apiErrorDiagnostics converts an API error into diagnostics.
+// Bad request field violations are reported on the matching attributes,
+// quota and precondition failures are listed, and common codes get a hint how to fix them.
+func apiErrorDiagnostics(summary string, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if err == nil {
+		return diags
+	}
+
+	st := grpcStatus(err)
+	var attrs []attributeViolation
+	var details []string
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				if p, ok := fieldPath(v.GetField()); ok {
+					attrs = append(attrs, attributeViolation{path: p, description: v.GetDescription()})
+				} else {
+					details = append(details, fmt.Sprintf("%s: %s", v.GetField(), v.GetDescription()))
+				}
+			}
+		case *errdetails.QuotaFailure:
+			for _, v := range d.GetViolations() {
+				details = append(details, fmt.Sprintf("quota %s: %s", v.GetSubject(), v.GetDescription()))
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.GetViolations() {
+				details = append(details, fmt.Sprintf("%s %s: %s", v.GetType(), v.GetSubject(), v.GetDescription()))
+			}
+		}
+	}
+
+	hint := errorHints[st.Code()]
+	if len(attrs) > 0 && len(details) == 0 {
+		// the status message is covered by the attribute errors, keep the hint on the first one
+		for i, a := range attrs {
+			description := a.description
+			if i == 0 && hint != "" {
+				description += "\n\n" + hint
+			}
+			diags.AddAttributeError(a.path, summary, description)
+		}
+		return diags
+	}
+	for _, a := range attrs {
+		diags.AddAttributeError(a.path, summary, a.description)
+	}
+
+	msg := err.Error()
+	if len(details) > 0 {
+		msg += "\n\n" + strings.Join(details, "\n")
+	}
+	if hint != "" {
+		msg += "\n\n" + hint
+	}
+	diags.AddError(summary, msg)
+	return diags
+}
+
+type attributeViolation struct {
+	path        path.Path
+	description string
+}
+
+var fieldSegment = regexp.MustCompile(`^([A-Za-z0-9_]+)(?:\[(\d+)\])?$`)
+
+// fieldPath converts API field reference like "resources.clickhouse.disk_size"
+// or "access.ipv4CidrBlocks[0]" into an attribute path.
+func fieldPath(field string) (path.Path, bool) {
+	if field == "" {
+		return path.Empty(), false
+	}
+	var p path.Path
+	for i, segment := range strings.Split(field, ".") {
+		m := fieldSegment.FindStringSubmatch(segment)
+		if m == nil {
+			return path.Empty(), false
+		}
+		name := toSnakeCase(m[1])
+		if i == 0 {
+			p = path.Root(name)
+		} else {
+			p = p.AtName(name)
+		}
+		if m[2] != "" {
+			idx, _ := strconv.Atoi(m[2])
+			p = p.AtListIndex(idx)
+		}
+	}
+	return p, true
+}
+
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("not found"), false},
+		{status.Error(codes.PermissionDenied, "denied"), false},
+		{status.Error(codes.NotFound, "cluster not found"), true},
+		{fmt.Errorf("wrapped: %w", status.Error(codes.NotFound, "cluster not found")), true},
+	} {
+		if got := isNotFoundError(tt.err); got != tt.want {
+			t.Errorf("isNotFoundError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestApiErrorDiagnostics(t *testing.T) {
+	badRequest, _ := status.New(codes.InvalidArgument, "invalid cluster spec").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "resources.clickhouse.diskSize", Description: "disk size is too small"},
+			{Field: "access.ipv4_cidr_blocks[1]", Description: "invalid CIDR"},
+		},
+	})
+	diags := apiErrorDiagnostics("failed to create", fmt.Errorf("operation failed: %w", badRequest.Err()))
+	if len(diags) != 2 {
+		t.Fatalf("expected attribute errors for each violation, got %v", diags)
+	}
+	want := []path.Path{
+		path.Root("resources").AtName("clickhouse").AtName("disk_size"),
+		path.Root("access").AtName("ipv4_cidr_blocks").AtListIndex(1),
+	}
+	for i, d := range diags {
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		if !ok || !withPath.Path().Equal(want[i]) {
+			t.Errorf("diagnostic %d: expected path %s, got %v", i, want[i], d)
+		}
+	}
+	if !strings.Contains(diags[0].Detail(), errorHints[codes.InvalidArgument]) {
+		t.Errorf("expected hint on the first attribute error, got %q", diags[0].Detail())
+	}
+
+	quota, _ := status.New(codes.ResourceExhausted, "quota exceeded").WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{Subject: "clickhouse.clusters.count", Description: "limit is 2"}},
+	})
+	diags = apiErrorDiagnostics("failed to create", quota.Err())
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail(), "quota clickhouse.clusters.count: limit is 2") || !strings.Contains(diags[0].Detail(), errorHints[codes.ResourceExhausted]) {
+		t.Errorf("unexpected quota diagnostics: %v", diags)
+	}
+
+	diags = apiErrorDiagnostics("failed to get", status.Error(codes.PermissionDenied, "denied"))
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail(), errorHints[codes.PermissionDenied]) {
+		t.Errorf("unexpected permission diagnostics: %v", diags)
+	}
+
+	diags = apiErrorDiagnostics("failed to get", errors.New("connection reset"))
+	if len(diags) != 1 || diags[0].Detail() != "connection reset" {
+		t.Errorf("unexpected diagnostics for plain error: %v", diags)
+	}
+}
@@ -3,12 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -26,12 +30,14 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NetworkResource{}
 var _ resource.ResourceWithImportState = &NetworkResource{}
+var _ resource.ResourceWithModifyPlan = &KafkaClusterResource{}
 
 func NewKafkaClusterResource() resource.Resource {
 	return &KafkaClusterResource{}
 }
 
 type KafkaClusterResource struct {
+	config         *Config
 	sdk            *dcsdk.SDK
 	clusterService *dcgen.ClusterServiceClient
 	userService    *dcgen.UserServiceClient
@@ -39,18 +45,41 @@ type KafkaClusterResource struct {
 }
 
 type KafkaClusterModel struct {
-	Id             types.String         `tfsdk:"id"`
-	ProjectID      types.String         `tfsdk:"project_id"`
-	CloudType      types.String         `tfsdk:"cloud_type"`
-	RegionID       types.String         `tfsdk:"region_id"`
-	Name           types.String         `tfsdk:"name"`
-	Description    types.String         `tfsdk:"description"`
-	Version        types.String         `tfsdk:"version"`
-	Resources      KafkaResourcesModel  `tfsdk:"resources"`
-	NetworkId      types.String         `tfsdk:"network_id"`
+	Id          types.String        `tfsdk:"id"`
+	ProjectID   types.String        `tfsdk:"project_id"`
+	CloudType   types.String        `tfsdk:"cloud_type"`
+	RegionID    types.String        `tfsdk:"region_id"`
+	Name        types.String        `tfsdk:"name"`
+	Description types.String        `tfsdk:"description"`
+	Version     types.String        `tfsdk:"version"`
+	Resources   KafkaResourcesModel `tfsdk:"resources"`
+	NetworkId   types.String        `tfsdk:"network_id"`
 	// Hide encryption due to deprecation
 	// Encryption     *DataEncryptionModel `tfsdk:"encryption"`
 	SchemaRegistry *schemaRegistryModel `tfsdk:"schema_registry"`
+	Timeouts       timeouts.Value       `tfsdk:"timeouts"`
+
+	ConnectionInfo        types.Object `tfsdk:"connection_info"`
+	PrivateConnectionInfo types.Object `tfsdk:"private_connection_info"`
+}
+
+type kafkaClusterConnectionInfoModel struct {
+	ConnectionString types.String `tfsdk:"connection_string"`
+	User             types.String `tfsdk:"user"`
+	Password         types.String `tfsdk:"password"`
+}
+
+var kafkaClusterConnectionInfoType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"connection_string": types.StringType,
+	"user":              types.StringType,
+	"password":          types.StringType,
+}}
+
+var kafkaTimeouts = resourceTimeouts{
+	Create: 60 * time.Minute,
+	Read:   5 * time.Minute,
+	Update: 60 * time.Minute,
+	Delete: 30 * time.Minute,
 }
 
 type DataEncryptionModel struct {
@@ -95,11 +124,9 @@ func (r *KafkaClusterResource) Schema(ctx context.Context, req resource.SchemaRe
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"project_id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "Project Id",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"cloud_type": schema.StringAttribute{
 				Required:            true,
@@ -139,6 +166,18 @@ func (r *KafkaClusterResource) Schema(ctx context.Context, req resource.SchemaRe
 				MarkdownDescription: "Version of Apache Kafka",
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
+			"connection_info": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Public connection info of cluster. It has no Schema Registry URL, the API doesn't return one even when `schema_registry` is enabled",
+				Attributes:          kafkaClusterConnectionInfoSchema(),
+				PlanModifiers:       []planmodifier.Object{objectplanmodifier.UseStateForUnknown()},
+			},
+			"private_connection_info": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Private connection info of cluster, available from peered networks. It has no Schema Registry URL, the API doesn't return one even when `schema_registry` is enabled",
+				Attributes:          kafkaClusterConnectionInfoSchema(),
+				PlanModifiers:       []planmodifier.Object{objectplanmodifier.UseStateForUnknown()},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"resources": schema.SingleNestedBlock{
@@ -173,34 +212,58 @@ func (r *KafkaClusterResource) Schema(ctx context.Context, req resource.SchemaRe
 					"enabled": schema.BoolAttribute{Computed: true, Optional: true},
 				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
 		},
 		MarkdownDescription: "Kafka cluster resource",
 		Version:             0,
 	}
 }
 
+func kafkaClusterConnectionInfoSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"connection_string": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Bootstrap servers to use in clients",
+		},
+		"user": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Apache Kafka® user",
+		},
+		"password": schema.StringAttribute{
+			Computed:            true,
+			Sensitive:           true,
+			MarkdownDescription: "Password for Apache Kafka® user",
+		},
+	}
+}
+
 func (r *KafkaClusterResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	sdk, ok := req.ProviderData.(*dcsdk.SDK)
+	config, ok := req.ProviderData.(*Config)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *dcsdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.sdk = sdk
+	r.config = config
+	r.sdk = config.sdk
 	r.clusterService = r.sdk.Kafka().Cluster()
 	r.userService = r.sdk.Kafka().User()
 	r.topicService = r.sdk.Kafka().Topic()
 }
 
+func (r *KafkaClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanProjectId(ctx, r.config, req, resp)
+}
+
 func createKafkaClusterRequest(m *KafkaClusterModel) (*kafka.CreateClusterRequest, diag.Diagnostics) {
 	rq := &kafka.CreateClusterRequest{}
 	rq.Name = m.Name.ValueString()
@@ -256,6 +319,8 @@ func cloudTypeValidator() validator.String {
 }
 
 func (r *KafkaClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.config.listings.invalidate("kafka")
+
 	var data *KafkaClusterModel
 
 	// Read Terraform plan data into the model
@@ -265,6 +330,14 @@ func (r *KafkaClusterResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, kafkaTimeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	rq, diag := createKafkaClusterRequest(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -272,18 +345,30 @@ func (r *KafkaClusterResource) Create(ctx context.Context, req resource.CreateRe
 	}
 	rs, err := r.clusterService.Create(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(rs, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
 	}
-	err = op.Wait(ctx)
+	data.Id = types.StringValue(op.ResourceId())
+
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		if op.ResourceId() != "" {
+			saveInterruptedCreate(ctx, resp, op, &data, err)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
+	}
+
+	resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	data.Id = types.StringValue(op.ResourceId())
 
 	// TODO: make a parse server response into model
 	getRq, diag := getKafkaClusterResourceRequest(data)
@@ -293,10 +378,11 @@ func (r *KafkaClusterResource) Create(ctx context.Context, req resource.CreateRe
 	}
 	cluster, err := r.clusterService.Get(ctx, getRq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to read", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to read", err)...)
 		return
 	}
 	data.Version = types.StringValue(cluster.Version)
+	resp.Diagnostics.Append(data.parseConnectionInfo(ctx, cluster)...)
 
 	tflog.Info(ctx, fmt.Sprintf("doublecloud_kafka_cluster has been created: %s", op.ResourceId()))
 
@@ -320,6 +406,20 @@ func (r *KafkaClusterResource) Read(ctx context.Context, req resource.ReadReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, kafkaTimeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(pollPendingOperation(ctx, r.sdk.WrapOperation, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Warning or errors can be collected in a slice type
 	// var diags diag.Diagnostics
 
@@ -330,7 +430,12 @@ func (r *KafkaClusterResource) Read(ctx context.Context, req resource.ReadReques
 	}
 	rs, err := r.clusterService.Get(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get", err.Error())
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("kafka cluster %s not found, removing from state", data.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 		return
 	}
 
@@ -350,6 +455,7 @@ func (r *KafkaClusterResource) Read(ctx context.Context, req resource.ReadReques
 	if rs.SchemaRegistryConfig != nil {
 		data.SchemaRegistry = &schemaRegistryModel{Enabled: types.BoolValue(rs.SchemaRegistryConfig.Enabled)}
 	}
+	resp.Diagnostics.Append(data.parseConnectionInfo(ctx, rs)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -381,6 +487,8 @@ func updateKafkaClusterRequest(m *KafkaClusterModel) (*kafka.UpdateClusterReques
 }
 
 func (r *KafkaClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.config.listings.invalidate("kafka")
+
 	var data *KafkaClusterModel
 
 	// Read Terraform plan data into the model
@@ -390,6 +498,19 @@ func (r *KafkaClusterResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, kafkaTimeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(waitPendingOperation(ctx, r.sdk.WrapOperation, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	rq, diag := updateKafkaClusterRequest(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -397,16 +518,18 @@ func (r *KafkaClusterResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 	rs, err := r.clusterService.Update(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(rs, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
+		return
 	}
-	err = op.Wait(ctx)
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
+		return
 	}
 
 	// Save updated data into Terraform state
@@ -414,6 +537,8 @@ func (r *KafkaClusterResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *KafkaClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.config.listings.invalidate("kafka")
+
 	var data *KafkaClusterModel
 
 	// Read Terraform prior state data into the model
@@ -423,6 +548,19 @@ func (r *KafkaClusterResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, kafkaTimeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(waitPendingOperation(ctx, r.sdk.WrapOperation, req.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	rq, diag := deleteKafkaClusterRequest(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -430,14 +568,46 @@ func (r *KafkaClusterResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 	rs, err := r.clusterService.Delete(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(rs, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+		return
+	}
+	err = waitOperation(ctx, op)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+	}
+}
+
+func (m *KafkaClusterModel) parseConnectionInfo(ctx context.Context, rs *kafka.Cluster) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	m.ConnectionInfo, d = parseKafkaConnectionInfo(ctx, rs.ConnectionInfo)
+	diags.Append(d...)
+	m.PrivateConnectionInfo, d = parseKafkaConnectionInfo(ctx, rs.PrivateConnectionInfo)
+	diags.Append(d...)
+	return diags
+}
+
+// kafkaConnectionInfo is implemented by public and private connection info.
+type kafkaConnectionInfo interface {
+	GetConnectionString() string
+	GetUser() string
+	GetPassword() string
+}
+
+func parseKafkaConnectionInfo(ctx context.Context, rs kafkaConnectionInfo) (types.Object, diag.Diagnostics) {
+	if rs.GetConnectionString() == "" {
+		return types.ObjectNull(kafkaClusterConnectionInfoType.AttrTypes), nil
+	}
+	m := kafkaClusterConnectionInfoModel{
+		ConnectionString: types.StringValue(rs.GetConnectionString()),
+		User:             types.StringValue(rs.GetUser()),
+		Password:         types.StringValue(rs.GetPassword()),
 	}
-	err = op.Wait(ctx)
+	return types.ObjectValueFrom(ctx, kafkaClusterConnectionInfoType.AttrTypes, m)
 }
 
 func (r *KafkaClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
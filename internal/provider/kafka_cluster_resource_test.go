@@ -3,6 +3,7 @@ package provider
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -71,6 +72,63 @@ func TestAccKafkaClusterResource(t *testing.T) {
 	})
 }
 
+func TestAccKafkaClusterResourceConnectionInfo(t *testing.T) {
+	t.Parallel()
+	if testFakeAPI == nil {
+		t.Skip("creates a cluster for every step")
+	}
+	id := fmt.Sprintf("doublecloud_kafka_cluster.%v", testAccKafkaName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaClusterConnectionInfoConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(id, "connection_info.connection_string"),
+					resource.TestCheckResourceAttr(id, "connection_info.user", "admin"),
+					resource.TestCheckResourceAttrSet(id, "connection_info.password"),
+					resource.TestCheckResourceAttrSet(id, "private_connection_info.connection_string"),
+				),
+			},
+			// Enabling Schema Registry keeps the connection info
+			{
+				Config: testAccKafkaClusterConnectionInfoConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(id, "connection_info.connection_string", regexp.MustCompile(`:9091$`)),
+					resource.TestMatchResourceAttr(id, "private_connection_info.connection_string", regexp.MustCompile(`\.private\.`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaClusterConnectionInfoConfig(schemaRegistry bool) string {
+	return fmt.Sprintf(`
+resource "doublecloud_kafka_cluster" %[2]q {
+  project_id = %[1]q
+  name = %[2]q
+  region_id = "eu-central-1"
+  cloud_type = "aws"
+  network_id = %[3]q
+
+  resources {
+    kafka {
+      resource_preset_id = "s1-c2-m4"
+      disk_size = 34359738368
+      broker_count = 1
+      zone_count = 1
+    }
+  }
+
+  schema_registry {
+    enabled = %[4]t
+  }
+}
+`, testProjectId, testAccKafkaName, testNetworkId, schemaRegistry)
+}
+
 // func testAccKafkaUsersResourceConfig(m *KafkaClusterModel) string {
 // 	if m.Users.IsNull() {
 // 		return "empty"
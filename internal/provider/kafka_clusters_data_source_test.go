@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccKafkaClustersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccKafkaClustersDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.doublecloud_kafka_clusters.test", "clusters.#", "1"),
+					resource.TestCheckResourceAttr("data.doublecloud_kafka_clusters.test", "clusters.0.name", testKafkaName),
+					resource.TestCheckResourceAttr("data.doublecloud_kafka_clusters.test", "clusters.0.region_id", "eu-central-1"),
+					resource.TestCheckResourceAttrSet("data.doublecloud_kafka_clusters.test", "clusters.0.version"),
+					resource.TestCheckResourceAttr("data.doublecloud_kafka_clusters.other_version", "clusters.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaClustersDataSourceConfig() string {
+	return fmt.Sprintf(`
+data "doublecloud_kafka_clusters" "test" {
+	project_id = %[1]q
+	name_regex = "^%[2]s$"
+	region_id = "eu-central-1"
+}
+
+data "doublecloud_kafka_clusters" "other_version" {
+	project_id = %[1]q
+	name_regex = "^%[2]s$"
+	version = "0.1"
+}`, testProjectId, testKafkaName)
+}
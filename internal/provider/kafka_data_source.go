@@ -21,8 +21,9 @@ func NewKafkaDataSource() datasource.DataSource {
 }
 
 type KafkaDataSource struct {
-	sdk *dcsdk.SDK
-	svc *dcgen.ClusterServiceClient
+	config *Config
+	sdk    *dcsdk.SDK
+	svc    *dcgen.ClusterServiceClient
 }
 
 type KafkaDataSourceModel struct {
@@ -69,7 +70,8 @@ func (d *KafkaDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 		MarkdownDescription: "Kafka data source",
 		Attributes: map[string]schema.Attribute{
 			"project_id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "Project identifier",
 			},
 			"id": schema.StringAttribute{
@@ -115,17 +117,18 @@ func (d *KafkaDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	sdk, ok := req.ProviderData.(*dcsdk.SDK)
+	config, ok := req.ProviderData.(*Config)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *dcsdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.sdk = sdk
+	d.config = config
+	d.sdk = config.sdk
 	d.svc = d.sdk.Kafka().Cluster()
 }
 
@@ -139,29 +142,30 @@ func (d *KafkaDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	projectId, diags := d.config.projectId(data.ProjectID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ProjectID = projectId
+
 	if data.Id == types.StringNull() && data.Name == types.StringNull() {
 		resp.Diagnostics.AddError("missing attribute", "specify one of: id or name")
 		return
 	}
 
 	if data.Id == types.StringNull() {
-		it := d.svc.ClusterIterator(ctx, &kafka.ListClustersRequest{ProjectId: data.ProjectID.ValueString()})
-		for it.Next() {
-			c := it.Value()
-			if c.Name == data.Name.ValueString() {
-				data.Id = types.StringValue(c.Id)
-				break
-			}
-		}
-		if data.Id == types.StringNull() {
-			resp.Diagnostics.AddError("cluster not found", fmt.Sprintf("clickhouse cluster `%v` haven't found", data.Name.ValueString()))
+		id, diags := lookupByName(ctx, "kafka cluster", data.ProjectID.ValueString(), data.Name.ValueString(), d.config.kafkaClusters)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+		data.Id = types.StringValue(id)
 	}
 
 	response, err := d.svc.Get(ctx, &kafka.GetClusterRequest{ClusterId: data.Id.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 		return
 	}
 
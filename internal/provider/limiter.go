@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc"
+)
+
+const defaultMaxConcurrentRequests = 8
+
+// apiServices are keys of per-service concurrency limits.
+var apiServices = []string{"clickhouse", "kafka", "network", "transfer", "visualization"}
+
+// requestLimiter bounds the number of API calls running at the same time,
+// both provider-wide and per service.
+type requestLimiter struct {
+	global   chan struct{}
+	services map[string]chan struct{}
+}
+
+// newRequestLimiter creates a limiter, zero limits are treated as unlimited.
+func newRequestLimiter(max int, perService map[string]int) *requestLimiter {
+	l := &requestLimiter{services: map[string]chan struct{}{}}
+	if max > 0 {
+		l.global = make(chan struct{}, max)
+	}
+	for service, limit := range perService {
+		if limit > 0 {
+			l.services[service] = make(chan struct{}, limit)
+		}
+	}
+	return l
+}
+
+// methodService returns service name of the gRPC method, e.g. clickhouse for
+// /doublecloud.clickhouse.v1.ClusterService/Get.
+func methodService(fullMethod string) string {
+	parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+func acquire(ctx context.Context, sem chan struct{}) error {
+	if sem == nil {
+		return nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func release(sem chan struct{}) {
+	if sem != nil {
+		<-sem
+	}
+}
+
+func (l *requestLimiter) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service := l.services[methodService(method)]
+		start := time.Now()
+		// per-service slot first, so calls waiting for a busy service don't block other services
+		if err := acquire(ctx, service); err != nil {
+			return err
+		}
+		defer release(service)
+		if err := acquire(ctx, l.global); err != nil {
+			return err
+		}
+		defer release(l.global)
+
+		if waited := time.Since(start); waited > time.Millisecond {
+			tflog.Debug(ctx, fmt.Sprintf("%s waited for concurrency limit", method), map[string]interface{}{
+				"waited":   waited.String(),
+				"in_use":   len(l.global),
+				"capacity": cap(l.global),
+			})
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc"
+)
+
+func TestMethodService(t *testing.T) {
+	for method, want := range map[string]string{
+		"/doublecloud.clickhouse.v1.ClusterService/Get":        "clickhouse",
+		"/doublecloud.network.v1.NetworkService/List":          "network",
+		"/doublecloud.visualization.v1.WorkbookService/Create": "visualization",
+		"/grpc.health.v1.Health/Check":                         "health",
+		"broken":                                               "",
+	} {
+		if got := methodService(method); got != want {
+			t.Errorf("methodService(%q) = %q, want %q", method, got, want)
+		}
+	}
+}
+
+func maxConcurrentCalls(l *requestLimiter, method string, calls int) int64 {
+	var running, max int64
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		n := atomic.AddInt64(&running, 1)
+		for {
+			m := atomic.LoadInt64(&max)
+			if n <= m || atomic.CompareAndSwapInt64(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt64(&running, -1)
+		return nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = l.interceptor()(context.Background(), method, nil, nil, nil, invoker)
+		}()
+	}
+	wg.Wait()
+	return max
+}
+
+func TestRequestLimiter(t *testing.T) {
+	l := newRequestLimiter(3, map[string]int{"clickhouse": 1})
+	if max := maxConcurrentCalls(l, "/doublecloud.kafka.v1.ClusterService/Get", 10); max > 3 {
+		t.Errorf("provider-wide limit exceeded: %d calls at once", max)
+	}
+	if max := maxConcurrentCalls(l, "/doublecloud.clickhouse.v1.ClusterService/Get", 10); max > 1 {
+		t.Errorf("per-service limit exceeded: %d calls at once", max)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l = newRequestLimiter(1, nil)
+	l.global <- struct{}{}
+	err := l.interceptor()(ctx, "/doublecloud.kafka.v1.ClusterService/Get", nil, nil, nil, nil)
+	if err != context.Canceled {
+		t.Errorf("expected canceled call while waiting for the limit, got %v", err)
+	}
+}
+
+func TestConfigureLimits(t *testing.T) {
+	ctx := context.Background()
+	conf := &Config{}
+	diags := configureLimits(ctx, &DoubleCloudProviderModel{MaxConcurrentRequestsPerService: types.MapNull(types.Int64Type)}, conf)
+	if diags.HasError() || conf.MaxConcurrentRequests != defaultMaxConcurrentRequests || len(conf.MaxConcurrentRequestsPerService) != 0 {
+		t.Errorf("unexpected defaults: %+v %v", conf, diags)
+	}
+
+	perService := types.MapValueMust(types.Int64Type, map[string]attr.Value{"kafka": types.Int64Value(2)})
+	diags = configureLimits(ctx, &DoubleCloudProviderModel{MaxConcurrentRequests: types.Int64Value(0), MaxConcurrentRequestsPerService: perService}, conf)
+	if diags.HasError() || conf.MaxConcurrentRequests != 0 || conf.MaxConcurrentRequestsPerService["kafka"] != 2 {
+		t.Errorf("unexpected limits: %+v %v", conf, diags)
+	}
+}
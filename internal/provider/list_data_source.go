@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/doublecloud/go-genproto/doublecloud/v1"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// enumName turns API enum names like CLUSTER_STATUS_ALIVE into alive.
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+// enumNames lists enum values as enumName returns them, without the zero value.
+func enumNames(names map[int32]string, prefix string) []string {
+	values := make([]string, 0, len(names))
+	for number, name := range names {
+		if number != 0 {
+			values = append(values, enumName(name, prefix))
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// listFilterAttributes returns filters of plural data sources,
+// every filter is optional and unset filters match everything.
+func listFilterAttributes(kind string, statuses []string, filters ...string) map[string]schema.Attribute {
+	all := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Identifier of the listing, same as project identifier",
+		},
+		"project_id": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: "Project identifier",
+		},
+		"name_regex": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Regular expression the name of %s must match", kind),
+		},
+		"region_id": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Region of %s", kind),
+		},
+		"cloud_type": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Cloud type of %s (aws, gcp, azure)", kind),
+		},
+		"version": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Version of %s", kind),
+		},
+		"status": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Status of %s (%s)", kind, strings.Join(statuses, ", ")),
+			Validators:          []validator.String{stringvalidator.OneOfCaseInsensitive(statuses...)},
+		},
+	}
+	attrs := map[string]schema.Attribute{"id": all["id"], "project_id": all["project_id"]}
+	for _, f := range filters {
+		attrs[f] = all[f]
+	}
+	return attrs
+}
+
+// compileNameRegex compiles name_regex filter, null matches every name.
+func compileNameRegex(v types.String) (*regexp.Regexp, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if v.IsNull() {
+		return nil, diags
+	}
+	re, err := regexp.Compile(v.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("name_regex"), "invalid regular expression", err.Error())
+	}
+	return re, diags
+}
+
+func matchNameRegex(re *regexp.Regexp, name string) bool {
+	return re == nil || re.MatchString(name)
+}
+
+// matchFilter reports whether v passes the filter, null filters match everything.
+func matchFilter(filter types.String, v string) bool {
+	return filter.IsNull() || strings.EqualFold(filter.ValueString(), v)
+}
+
+const clusterStatusPrefix = "CLUSTER_STATUS_"
+
+// clustersDataSourceModel is shared by plural data sources of clusters.
+type clustersDataSourceModel struct {
+	Id        types.String        `tfsdk:"id"`
+	ProjectID types.String        `tfsdk:"project_id"`
+	NameRegex types.String        `tfsdk:"name_regex"`
+	RegionID  types.String        `tfsdk:"region_id"`
+	CloudType types.String        `tfsdk:"cloud_type"`
+	Version   types.String        `tfsdk:"version"`
+	Status    types.String        `tfsdk:"status"`
+	Clusters  []clustersItemModel `tfsdk:"clusters"`
+}
+
+type clustersItemModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	RegionID    types.String `tfsdk:"region_id"`
+	CloudType   types.String `tfsdk:"cloud_type"`
+	Version     types.String `tfsdk:"version"`
+	NetworkId   types.String `tfsdk:"network_id"`
+	Status      types.String `tfsdk:"status"`
+}
+
+func clustersDataSourceSchema(kind string) schema.Schema {
+	attrs := listFilterAttributes(kind, enumNames(doublecloud.ClusterStatus_name, clusterStatusPrefix),
+		"name_regex", "region_id", "cloud_type", "version", "status")
+	attrs["clusters"] = schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "Clusters matching the filters",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Cluster identifier",
+				},
+				"name": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Name of cluster",
+				},
+				"description": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Description of cluster",
+				},
+				"region_id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Region of cluster",
+				},
+				"cloud_type": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Cloud type (aws, gcp, azure)",
+				},
+				"version": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: fmt.Sprintf("Version of %s", kind),
+				},
+				"network_id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Network of cluster",
+				},
+				"status": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Status of cluster",
+				},
+			},
+		},
+	}
+
+	return schema.Schema{
+		MarkdownDescription: fmt.Sprintf("%s clusters data source lists clusters of the project", kind),
+		Attributes:          attrs,
+	}
+}
+
+// add appends the cluster to the list when it passes the filters.
+func (m *clustersDataSourceModel) add(re *regexp.Regexp, id, name, description, regionId, cloudType, version, networkId string, status doublecloud.ClusterStatus) {
+	s := enumName(status.String(), clusterStatusPrefix)
+	if !matchNameRegex(re, name) ||
+		!matchFilter(m.RegionID, regionId) ||
+		!matchFilter(m.CloudType, cloudType) ||
+		!matchFilter(m.Version, version) ||
+		!matchFilter(m.Status, s) {
+		return
+	}
+	m.Clusters = append(m.Clusters, clustersItemModel{
+		Id:          types.StringValue(id),
+		Name:        types.StringValue(name),
+		Description: types.StringValue(description),
+		RegionID:    types.StringValue(regionId),
+		CloudType:   types.StringValue(cloudType),
+		Version:     types.StringValue(version),
+		NetworkId:   types.StringValue(networkId),
+		Status:      types.StringValue(s),
+	})
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/doublecloud/go-genproto/doublecloud/clickhouse/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/kafka/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// listingCache keeps project listings of every service for the provider
+// lifetime, so data sources looking up objects by name list a project once
+// until a resource of the service changes.
+// Data sources returning whole listings always list the project again,
+// they must not miss objects created or deleted by the same apply.
+type listingCache struct {
+	mu       sync.Mutex
+	listings map[listingKey]*listing
+}
+
+type listingKey struct {
+	service   string
+	projectId string
+}
+
+// listing is filled once, concurrent readers of the same key wait for it.
+type listing struct {
+	once  sync.Once
+	items any
+	err   error
+}
+
+func newListingCache() *listingCache {
+	return &listingCache{listings: map[listingKey]*listing{}}
+}
+
+// get returns listing of the key, fresh drops the cached one first.
+func (c *listingCache) get(key listingKey, fresh bool) *listing {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	l, ok := c.listings[key]
+	if !ok || fresh {
+		l = &listing{}
+		c.listings[key] = l
+	}
+	return l
+}
+
+// forget drops the listing unless it has been replaced already.
+func (c *listingCache) forget(key listingKey, l *listing) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.listings[key] == l {
+		delete(c.listings, key)
+	}
+}
+
+// invalidate drops listings of the service in every project. Resources call it
+// whenever they create, rename or delete objects, so later lookups by name
+// neither return identifiers of deleted objects nor miss new namesakes.
+func (c *listingCache) invalidate(service string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.listings {
+		if key.service == service {
+			delete(c.listings, key)
+		}
+	}
+}
+
+// cachedList lists the project with list once per provider instance,
+// failed listings are not cached.
+func cachedList[T any](ctx context.Context, c *listingCache, service, projectId string, fresh bool, list func(context.Context) ([]T, error)) ([]T, error) {
+	key := listingKey{service: service, projectId: projectId}
+	l := c.get(key, fresh)
+	l.once.Do(func() {
+		tflog.Debug(ctx, "listing project", map[string]interface{}{"service": service, "project_id": projectId})
+		l.items, l.err = list(ctx)
+	})
+	if l.err != nil {
+		c.forget(key, l)
+		return nil, l.err
+	}
+	return l.items.([]T), nil
+}
+
+type namedObject interface {
+	GetId() string
+	GetName() string
+}
+
+func matchingIds[T namedObject](name string, items []T) []string {
+	var ids []string
+	for _, v := range items {
+		if v.GetName() == name {
+			ids = append(ids, v.GetId())
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// lookupByName resolves the name using cached listing of the project,
+// names matching several objects are reported with their identifiers.
+func lookupByName[T namedObject](ctx context.Context, kind, projectId, name string, list func(ctx context.Context, projectId string, fresh bool) ([]T, error)) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	items, err := list(ctx, projectId, false)
+	if err != nil {
+		return "", apiErrorDiagnostics("failed to list", err)
+	}
+	ids := matchingIds(name, items)
+	if len(ids) == 0 {
+		// Objects created after the listing are missing from it
+		items, err = list(ctx, projectId, true)
+		if err != nil {
+			return "", apiErrorDiagnostics("failed to list", err)
+		}
+		ids = matchingIds(name, items)
+	}
+
+	switch len(ids) {
+	case 0:
+		diags.AddError(kind+" not found", fmt.Sprintf("%v `%v` haven't found", kind, name))
+		return "", diags
+	case 1:
+		return ids[0], diags
+	}
+	diags.AddAttributeError(
+		path.Root("name"),
+		"ambiguous "+kind+" name",
+		fmt.Sprintf("found %d objects named `%v`: %v. Specify id instead of name", len(ids), name, strings.Join(ids, ", ")),
+	)
+	return "", diags
+}
+
+func (c *Config) networks(ctx context.Context, projectId string, fresh bool) ([]*network.Network, error) {
+	return cachedList(ctx, c.listings, "network", projectId, fresh, func(ctx context.Context) ([]*network.Network, error) {
+		it := c.sdk.Network().Network().NetworkIterator(ctx, &network.ListNetworksRequest{ProjectId: projectId})
+		return it.TakeAll()
+	})
+}
+
+func (c *Config) clickhouseClusters(ctx context.Context, projectId string, fresh bool) ([]*clickhouse.Cluster, error) {
+	return cachedList(ctx, c.listings, "clickhouse", projectId, fresh, func(ctx context.Context) ([]*clickhouse.Cluster, error) {
+		it := c.sdk.ClickHouse().Cluster().ClusterIterator(ctx, &clickhouse.ListClustersRequest{ProjectId: projectId})
+		return it.TakeAll()
+	})
+}
+
+func (c *Config) kafkaClusters(ctx context.Context, projectId string, fresh bool) ([]*kafka.Cluster, error) {
+	return cachedList(ctx, c.listings, "kafka", projectId, fresh, func(ctx context.Context) ([]*kafka.Cluster, error) {
+		it := c.sdk.Kafka().Cluster().ClusterIterator(ctx, &kafka.ListClustersRequest{ProjectId: projectId})
+		return it.TakeAll()
+	})
+}
+
+func (c *Config) transfers(ctx context.Context, projectId string, fresh bool) ([]*transfer.Transfer, error) {
+	return cachedList(ctx, c.listings, "transfer", projectId, fresh, func(ctx context.Context) ([]*transfer.Transfer, error) {
+		it := c.sdk.Transfer().Transfer().TransferIterator(ctx, &transfer.ListTransfersRequest{ProjectId: projectId})
+		return it.TakeAll()
+	})
+}
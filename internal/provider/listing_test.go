@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+)
+
+func TestCachedList(t *testing.T) {
+	ctx := context.Background()
+	c := newListingCache()
+	calls := 0
+	list := func(context.Context) ([]*network.Network, error) {
+		calls++
+		return []*network.Network{{Id: "net1", Name: "net"}}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cachedList(ctx, c, "network", "project", false, list); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("expected one listing, got %d", calls)
+	}
+
+	if _, err := cachedList(ctx, c, "network", "other", false, list); err != nil || calls != 2 {
+		t.Errorf("expected listing of other project, got %d (%v)", calls, err)
+	}
+	if _, err := cachedList(ctx, c, "network", "project", true, list); err != nil || calls != 3 {
+		t.Errorf("expected fresh listing, got %d (%v)", calls, err)
+	}
+
+	failing := func(context.Context) ([]*network.Network, error) {
+		calls++
+		return nil, errors.New("unavailable")
+	}
+	if _, err := cachedList(ctx, c, "kafka", "project", false, failing); err == nil {
+		t.Errorf("expected error")
+	}
+	if _, err := cachedList(ctx, c, "kafka", "project", false, list); err != nil || calls != 5 {
+		t.Errorf("expected failed listing not to be cached, got %d (%v)", calls, err)
+	}
+}
+
+func TestLookupByName(t *testing.T) {
+	ctx := context.Background()
+	c := newListingCache()
+	items := []*network.Network{
+		{Id: "net2", Name: "dup"},
+		{Id: "net1", Name: "dup"},
+		{Id: "net3", Name: "single"},
+	}
+	calls := 0
+	list := func(ctx context.Context, projectId string, fresh bool) ([]*network.Network, error) {
+		return cachedList(ctx, c, "network", projectId, fresh, func(context.Context) ([]*network.Network, error) {
+			calls++
+			return items, nil
+		})
+	}
+
+	id, diags := lookupByName(ctx, "network", "project", "single", list)
+	if diags.HasError() || id != "net3" {
+		t.Errorf("expected net3, got %v (%v)", id, diags)
+	}
+
+	_, diags = lookupByName(ctx, "network", "project", "dup", list)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "net1, net2") {
+		t.Errorf("expected ambiguity error listing candidates, got %v", diags)
+	}
+	if calls != 1 {
+		t.Errorf("expected cached listing, got %d listings", calls)
+	}
+
+	// objects created after the listing are found by listing again
+	items = append(items, &network.Network{Id: "net4", Name: "new"})
+	id, diags = lookupByName(ctx, "network", "project", "new", list)
+	if diags.HasError() || id != "net4" || calls != 2 {
+		t.Errorf("expected net4 after fresh listing, got %v after %d listings (%v)", id, calls, diags)
+	}
+
+	// objects recreated or added under a cached name are found once resources invalidate the listing
+	items = []*network.Network{
+		{Id: "net5", Name: "single"},
+		{Id: "net4", Name: "new"},
+		{Id: "net6", Name: "new"},
+	}
+	c.invalidate("network")
+	id, diags = lookupByName(ctx, "network", "project", "single", list)
+	if diags.HasError() || id != "net5" || calls != 3 {
+		t.Errorf("expected recreated net5, got %v after %d listings (%v)", id, calls, diags)
+	}
+	_, diags = lookupByName(ctx, "network", "project", "new", list)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "net4, net6") {
+		t.Errorf("expected ambiguity error for added namesake, got %v", diags)
+	}
+
+	_, diags = lookupByName(ctx, "network", "project", "missing", list)
+	if !diags.HasError() || diags[0].Summary() != "network not found" {
+		t.Errorf("expected not found error, got %v", diags)
+	}
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/genproto/googleapis/type/dayofweek"
+
+	"github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+type maintenanceWindowModel struct {
+	Anytime types.Bool   `tfsdk:"anytime"`
+	Day     types.String `tfsdk:"day"`
+	Hour    types.Int64  `tfsdk:"hour"`
+}
+
+func maintenanceWindowSchemaBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Maintenance window of the cluster. Without the block maintenance may start at any time",
+		Attributes: map[string]schema.Attribute{
+			"anytime": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maintenance may start at any time, the only accepted value is `true`",
+				Validators: []validator.Bool{
+					maintenanceAnytime{},
+					boolvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("day")),
+				},
+			},
+			"day": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Day of the weekly maintenance window (%s)", strings.Join(enumNames(dayofweek.DayOfWeek_name, ""), ", ")),
+				Validators: []validator.String{
+					stringvalidator.OneOf(enumNames(dayofweek.DayOfWeek_name, "")...),
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("anytime")),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("hour")),
+				},
+			},
+			"hour": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Hour of the weekly maintenance window in UTC (1 - 24)",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 24),
+					int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("day")),
+				},
+			},
+		},
+	}
+}
+
+// maintenanceAnytime rejects `anytime = false`, which selects no policy.
+type maintenanceAnytime struct{}
+
+func (v maintenanceAnytime) Description(ctx context.Context) string {
+	return "value must be true, set day and hour for a weekly window instead"
+}
+
+func (v maintenanceAnytime) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v maintenanceAnytime) ValidateBool(ctx context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if !req.ConfigValue.ValueBool() {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid maintenance window", v.Description(ctx))
+	}
+}
+
+func (m *maintenanceWindowModel) convert() (*doublecloud.MaintenanceWindow, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	// removing the block returns the cluster to the default policy
+	if m == nil || m.Anytime.ValueBool() {
+		return &doublecloud.MaintenanceWindow{
+			Policy: &doublecloud.MaintenanceWindow_Anytime{Anytime: &doublecloud.AnytimeMaintenanceWindow{}},
+		}, diags
+	}
+
+	day, ok := dayofweek.DayOfWeek_value[strings.ToUpper(m.Day.ValueString())]
+	if !ok || day == 0 {
+		diags.AddError("invalid maintenance window", fmt.Sprintf("unknown day of week %q", m.Day.ValueString()))
+		return nil, diags
+	}
+	return &doublecloud.MaintenanceWindow{
+		Policy: &doublecloud.MaintenanceWindow_WeeklyMaintenanceWindow{
+			WeeklyMaintenanceWindow: &doublecloud.WeeklyMaintenanceWindow{
+				Day:  dayofweek.DayOfWeek(day),
+				Hour: m.Hour.ValueInt64(),
+			},
+		},
+	}, diags
+}
+
+// parseMaintenanceWindow returns maintenance window of the cluster, keeping
+// the block absent if it isn't configured and the cluster has the default policy.
+func parseMaintenanceWindow(m *maintenanceWindowModel, rs *doublecloud.MaintenanceWindow) *maintenanceWindowModel {
+	weekly := rs.GetWeeklyMaintenanceWindow()
+	if m == nil && weekly == nil {
+		return nil
+	}
+
+	if weekly == nil {
+		return &maintenanceWindowModel{
+			Anytime: types.BoolValue(true),
+			Day:     types.StringNull(),
+			Hour:    types.Int64Null(),
+		}
+	}
+	return &maintenanceWindowModel{
+		Anytime: types.BoolNull(),
+		Day:     types.StringValue(enumName(weekly.Day.String(), "")),
+		Hour:    types.Int64Value(weekly.Hour),
+	}
+}
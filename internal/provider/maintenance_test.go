@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/genproto/googleapis/type/dayofweek"
+
+	"github.com/doublecloud/go-genproto/doublecloud/v1"
+)
+
+func TestMaintenanceAnytimeValidator(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		value types.Bool
+		err   bool
+	}{
+		{value: types.BoolNull()},
+		{value: types.BoolValue(true)},
+		{value: types.BoolValue(false), err: true},
+	} {
+		rs := validator.BoolResponse{}
+		maintenanceAnytime{}.ValidateBool(ctx, validator.BoolRequest{
+			Path:        path.Root("anytime"),
+			ConfigValue: tc.value,
+		}, &rs)
+		if rs.Diagnostics.HasError() != tc.err {
+			t.Errorf("%v: expected error %v, got %v", tc.value, tc.err, rs.Diagnostics)
+		}
+	}
+}
+
+func TestMaintenanceWindowRoundTrip(t *testing.T) {
+	m := &maintenanceWindowModel{
+		Anytime: types.BoolNull(),
+		Day:     types.StringValue("saturday"),
+		Hour:    types.Int64Value(22),
+	}
+	rs, diags := m.convert()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if w := rs.GetWeeklyMaintenanceWindow(); w.GetDay() != dayofweek.DayOfWeek_SATURDAY || w.GetHour() != 22 {
+		t.Errorf("unexpected weekly window %v", w)
+	}
+	if parsed := parseMaintenanceWindow(nil, rs); parsed == nil || *parsed != *m {
+		t.Errorf("expected %v, got %v", m, parsed)
+	}
+
+	// absent block resets the cluster to the default policy
+	rs, _ = (*maintenanceWindowModel)(nil).convert()
+	if rs.GetAnytime() == nil {
+		t.Errorf("expected anytime policy, got %v", rs)
+	}
+	if parseMaintenanceWindow(nil, rs) != nil {
+		t.Errorf("expected unconfigured default policy to stay absent")
+	}
+	parsed := parseMaintenanceWindow(&maintenanceWindowModel{Anytime: types.BoolValue(true)}, rs)
+	if parsed == nil || !parsed.Anytime.ValueBool() || !parsed.Day.IsNull() {
+		t.Errorf("expected configured anytime policy, got %v", parsed)
+	}
+	if parseMaintenanceWindow(nil, &doublecloud.MaintenanceWindow{}) != nil {
+		t.Errorf("expected missing policy to stay absent")
+	}
+}
@@ -0,0 +1,556 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+	dcsdk "github.com/doublecloud/go-sdk"
+	dcgennet "github.com/doublecloud/go-sdk/gen/network"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NetworkConnectionResource{}
+var _ resource.ResourceWithImportState = &NetworkConnectionResource{}
+var _ resource.ResourceWithConfigValidators = &NetworkConnectionResource{}
+
+func NewNetworkConnectionResource() resource.Resource {
+	return &NetworkConnectionResource{}
+}
+
+type NetworkConnectionResource struct {
+	config *Config
+	sdk    *dcsdk.SDK
+	svc    *dcgennet.NetworkConnectionServiceClient
+}
+
+type networkConnectionModel struct {
+	Id            types.String                  `tfsdk:"id"`
+	NetworkId     types.String                  `tfsdk:"network_id"`
+	Description   types.String                  `tfsdk:"description"`
+	Status        types.String                  `tfsdk:"status"`
+	StatusReason  types.String                  `tfsdk:"status_reason"`
+	WaitForActive types.Bool                    `tfsdk:"wait_for_active"`
+	AWS           *networkConnectionAWSModel    `tfsdk:"aws"`
+	Google        *networkConnectionGoogleModel `tfsdk:"google"`
+	Timeouts      timeouts.Value                `tfsdk:"timeouts"`
+}
+
+type networkConnectionAWSModel struct {
+	Peering *networkConnectionAWSPeeringModel `tfsdk:"peering"`
+}
+
+type networkConnectionAWSPeeringModel struct {
+	VpcId                types.String `tfsdk:"vpc_id"`
+	AccountId            types.String `tfsdk:"account_id"`
+	RegionId             types.String `tfsdk:"region_id"`
+	Ipv4CidrBlock        types.String `tfsdk:"ipv4_cidr_block"`
+	Ipv6CidrBlock        types.String `tfsdk:"ipv6_cidr_block"`
+	PeeringConnectionId  types.String `tfsdk:"peering_connection_id"`
+	ManagedIpv4CidrBlock types.String `tfsdk:"managed_ipv4_cidr_block"`
+	ManagedIpv6CidrBlock types.String `tfsdk:"managed_ipv6_cidr_block"`
+}
+
+type networkConnectionGoogleModel struct {
+	Name              types.String `tfsdk:"name"`
+	PeerNetworkUrl    types.String `tfsdk:"peer_network_url"`
+	ManagedNetworkUrl types.String `tfsdk:"managed_network_url"`
+}
+
+var networkConnectionTimeouts = resourceTimeouts{
+	Create: 20 * time.Minute,
+	Read:   5 * time.Minute,
+	Delete: 20 * time.Minute,
+}
+
+// networkConnectionPollInterval is how often the connection status is checked
+// after the create operation has finished.
+var networkConnectionPollInterval = 10 * time.Second
+
+func (r *NetworkConnectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_connection"
+}
+
+func (r *NetworkConnectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Network connection resource. Connects a DoubleCloud network to an AWS VPC or a GCP network with peering. " +
+			"Creation waits for the peer side to accept the connection, set `wait_for_active = false` to finish " +
+			"once the connection is pending and use the computed attributes to accept the peering and add routes on your side",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Network connection identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Network identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Description of network connection",
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Status of network connection (creating, pending, active, deleting, error). Pending connections wait for the peer side to accept them",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status_reason": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Reason of the last status change",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"wait_for_active": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Wait for the connection to become active on creation. Set to `false` if the peering is accepted later, for example by a module that needs the computed attributes of this resource",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"aws": schema.SingleNestedBlock{
+				MarkdownDescription: "AWS connection",
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRelative().AtName("peering")),
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Blocks: map[string]schema.Block{
+					"peering": networkConnectionAWSPeeringSchemaBlock(),
+				},
+			},
+			"google": schema.SingleNestedBlock{
+				MarkdownDescription: "GCP network peering",
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("name"),
+						path.MatchRelative().AtName("peer_network_url"),
+					),
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Name of the peering. It must be 1-63 characters long and match the regular expression `[a-z]([-a-z0-9]*[a-z0-9])?`",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"peer_network_url": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "URL of the peer network, either full or partial one containing the project",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"managed_network_url": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "URL of the DoubleCloud network to create the peering from the peer network to",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Delete: true}),
+		},
+	}
+}
+
+func networkConnectionAWSPeeringSchemaBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "AWS VPC peering",
+		Validators: []validator.Object{
+			objectvalidator.AlsoRequires(
+				path.MatchRelative().AtName("vpc_id"),
+				path.MatchRelative().AtName("account_id"),
+				path.MatchRelative().AtName("region_id"),
+				path.MatchRelative().AtName("ipv4_cidr_block"),
+			),
+		},
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"vpc_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the peer VPC",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the AWS account owning the peer VPC",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Region of the peer VPC",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv4_cidr_block": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "IPv4 CIDR block of the peer VPC, DoubleCloud routes it through the peering",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv6_cidr_block": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "IPv6 CIDR block of the peer VPC, DoubleCloud routes it through the peering",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"peering_connection_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the VPC peering connection to accept on the peer side",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"managed_ipv4_cidr_block": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "IPv4 CIDR block of the DoubleCloud network to route through the peering on the peer side",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"managed_ipv6_cidr_block": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "IPv6 CIDR block of the DoubleCloud network to route through the peering on the peer side",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NetworkConnectionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("aws"), path.MatchRoot("google")),
+	}
+}
+
+func (r *NetworkConnectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*Config)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.config = config
+	r.sdk = config.sdk
+	r.svc = r.sdk.Network().NetworkConnection()
+}
+
+func createNetworkConnectionRequest(m *networkConnectionModel) (*network.CreateNetworkConnectionRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rq := &network.CreateNetworkConnectionRequest{
+		NetworkId:   m.NetworkId.ValueString(),
+		Description: m.Description.ValueString(),
+	}
+	switch {
+	case m.AWS != nil && m.AWS.Peering != nil:
+		p := m.AWS.Peering
+		rq.Params = &network.CreateNetworkConnectionRequest_Aws{Aws: &network.CreateAWSNetworkConnectionRequest{
+			Type: &network.CreateAWSNetworkConnectionRequest_Peering{Peering: &network.CreateAWSNetworkConnectionPeeringRequest{
+				VpcId:         p.VpcId.ValueString(),
+				AccountId:     p.AccountId.ValueString(),
+				RegionId:      p.RegionId.ValueString(),
+				Ipv4CidrBlock: p.Ipv4CidrBlock.ValueString(),
+				Ipv6CidrBlock: p.Ipv6CidrBlock.ValueString(),
+			}},
+		}}
+	case m.Google != nil:
+		rq.Params = &network.CreateNetworkConnectionRequest_Google{Google: &network.CreateGoogleNetworkConnectionRequest{
+			Name:           m.Google.Name.ValueString(),
+			PeerNetworkUrl: m.Google.PeerNetworkUrl.ValueString(),
+		}}
+	default:
+		diags.AddError("unknown connection type", "one of aws.peering or google blocks is required")
+	}
+	return rq, diags
+}
+
+func (m *networkConnectionModel) parse(c *network.NetworkConnection) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = types.StringValue(c.Id)
+	m.NetworkId = types.StringValue(c.NetworkId)
+	m.Description = types.StringValue(c.Description)
+	m.Status = types.StringValue(networkConnectionStatus(c.Status))
+	m.StatusReason = types.StringValue(c.StatusReason)
+
+	switch info := c.ConnectionInfo.(type) {
+	case *network.NetworkConnection_Aws:
+		peering := info.Aws.GetPeering()
+		if peering == nil {
+			diags.AddError("unknown connection type", fmt.Sprintf("unsupported AWS connection %T", info.Aws.GetType()))
+			return diags
+		}
+		if m.AWS == nil {
+			m.AWS = &networkConnectionAWSModel{}
+		}
+		if m.AWS.Peering == nil {
+			m.AWS.Peering = &networkConnectionAWSPeeringModel{}
+		}
+		p := m.AWS.Peering
+		p.VpcId = types.StringValue(peering.VpcId)
+		p.AccountId = types.StringValue(peering.AccountId)
+		p.RegionId = types.StringValue(peering.RegionId)
+		p.Ipv4CidrBlock = types.StringValue(peering.Ipv4CidrBlock)
+		p.Ipv6CidrBlock = parseOptionalString(p.Ipv6CidrBlock, peering.Ipv6CidrBlock)
+		p.PeeringConnectionId = types.StringValue(peering.PeeringConnectionId)
+		p.ManagedIpv4CidrBlock = types.StringValue(peering.ManagedIpv4CidrBlock)
+		p.ManagedIpv6CidrBlock = types.StringValue(peering.ManagedIpv6CidrBlock)
+		m.Google = nil
+	case *network.NetworkConnection_Google:
+		if m.Google == nil {
+			m.Google = &networkConnectionGoogleModel{}
+		}
+		m.Google.Name = types.StringValue(info.Google.Name)
+		m.Google.PeerNetworkUrl = types.StringValue(info.Google.PeerNetworkUrl)
+		m.Google.ManagedNetworkUrl = types.StringValue(info.Google.ManagedNetworkUrl)
+		m.AWS = nil
+	default:
+		diags.AddError("unknown connection type", fmt.Sprintf("unsupported connection %T", c.ConnectionInfo))
+	}
+	return diags
+}
+
+func networkConnectionStatus(s network.NetworkConnection_NetworkConnectionStatus) string {
+	return enumName(s.String(), "NETWORK_CONNECTION_STATUS_")
+}
+
+// waitNetworkConnection waits for the connection to become active. Unless active is set,
+// it returns once the connection is pending: the peer side may accept the connection
+// only with the attributes returned here, so waiting for it would never end.
+func (r *NetworkConnectionResource) waitNetworkConnection(ctx context.Context, id string, active bool) (*network.NetworkConnection, error) {
+	for {
+		c, err := r.svc.Get(ctx, &network.GetNetworkConnectionRequest{NetworkConnectionId: id})
+		if err != nil {
+			return nil, err
+		}
+		switch c.Status {
+		case network.NetworkConnection_NETWORK_CONNECTION_STATUS_PENDING:
+			if !active {
+				return c, nil
+			}
+		case network.NetworkConnection_NETWORK_CONNECTION_STATUS_ACTIVE:
+			return c, nil
+		case network.NetworkConnection_NETWORK_CONNECTION_STATUS_ERROR:
+			return c, fmt.Errorf("network connection %s failed: %s", id, c.StatusReason)
+		}
+		tflog.Info(ctx, fmt.Sprintf("network connection %s is %s", id, networkConnectionStatus(c.Status)))
+		select {
+		case <-ctx.Done():
+			return c, fmt.Errorf("timed out waiting for network connection %s, it is still %s: %w", id, networkConnectionStatus(c.Status), ctx.Err())
+		case <-time.After(networkConnectionPollInterval):
+		}
+	}
+}
+
+func (r *NetworkConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *networkConnectionModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, networkConnectionTimeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	rq, diags := createNetworkConnectionRequest(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	dcOperation, err := r.svc.Create(ctx, rq)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
+	}
+	op, err := r.sdk.WrapOperation(dcOperation, err)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
+	}
+	data.Id = types.StringValue(op.ResourceId())
+
+	err = waitOperation(ctx, op)
+	if err != nil {
+		if op.ResourceId() != "" {
+			saveFailedCreate(ctx, resp, &data, err)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
+	}
+
+	resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, err := r.waitNetworkConnection(ctx, data.Id.ValueString(), data.WaitForActive.ValueBool())
+	if c != nil {
+		resp.Diagnostics.Append(data.parse(c)...)
+	}
+	if err != nil {
+		resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *networkConnectionModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, networkConnectionTimeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	c, err := r.svc.Get(ctx, &network.GetNetworkConnectionRequest{NetworkConnectionId: data.Id.ValueString()})
+	if err != nil {
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("network connection %s not found, removing from state", data.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
+		return
+	}
+	resp.Diagnostics.Append(data.parse(c)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *networkConnectionModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// all connection attributes require replacement, only wait_for_active and timeouts can change in place
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *networkConnectionModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, networkConnectionTimeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	dcOperation, err := r.svc.Delete(ctx, &network.DeleteNetworkConnectionRequest{NetworkConnectionId: data.Id.ValueString()})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+		return
+	}
+	op, err := r.sdk.WrapOperation(dcOperation, err)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+		return
+	}
+	err = waitOperation(ctx, op)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+	}
+}
+
+func (r *NetworkConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_active"), true)...)
+}
@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+)
+
+var (
+	testAccNetworkConnectionName string = fmt.Sprintf("%v-connection", testPrefix)
+	testAccNetworkConnectionId   string = fmt.Sprintf("doublecloud_network_connection.%v", testAccNetworkConnectionName)
+)
+
+func TestAccNetworkConnectionResource(t *testing.T) {
+	t.Parallel()
+	if testFakeAPI == nil {
+		t.Skip("peering needs a VPC to accept it on the peer side")
+	}
+	networkConnectionPollInterval = 100 * time.Millisecond
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNetworkConnectionConfig(`aws {}`),
+				ExpectError: regexp.MustCompile(`peering`),
+			},
+			{
+				Config: testAccNetworkConnectionConfig(`
+  aws {
+    peering {
+      vpc_id          = "vpc-0123456789"
+      account_id      = "123456789012"
+      region_id       = "eu-central-1"
+      ipv4_cidr_block = "172.16.0.0/16"
+    }
+  }
+  google {
+    name             = "peering"
+    peer_network_url = "projects/test/global/networks/default"
+  }`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccNetworkConnectionConfig(`
+  wait_for_active = false
+  aws {
+    peering {
+      vpc_id          = "vpc-0123456789"
+      account_id      = "123456789012"
+      region_id       = "eu-central-1"
+      ipv4_cidr_block = "172.16.0.0/16"
+    }
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccNetworkConnectionId, "status", "pending"),
+					resource.TestCheckResourceAttr(testAccNetworkConnectionId, "wait_for_active", "false"),
+					resource.TestCheckResourceAttrSet(testAccNetworkConnectionId, "aws.peering.peering_connection_id"),
+					resource.TestCheckResourceAttr(testAccNetworkConnectionId, "aws.peering.managed_ipv4_cidr_block", "10.1.0.0/16"),
+					resource.TestCheckNoResourceAttr(testAccNetworkConnectionId, "aws.peering.ipv6_cidr_block"),
+					acceptNetworkConnection(testAccNetworkConnectionId),
+				),
+			},
+			// Refresh picks up accepted connection
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccNetworkConnectionId, "status", "active"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      testAccNetworkConnectionId,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"timeouts",
+					"wait_for_active",
+				},
+			},
+			// Creation waits for the connection to become active by default
+			{
+				Config: testAccNetworkConnectionConfig(`
+  google {
+    name             = "peering"
+    peer_network_url = "projects/test/global/networks/default"
+  }
+  timeouts {
+    create = "2s"
+  }`),
+				ExpectError: regexp.MustCompile(`timed out waiting for network connection [a-z0-9]+, it is still\s+pending`),
+			},
+			// Switching to GCP replaces the connection
+			{
+				PreConfig: acceptPendingNetworkConnections(t),
+				Config: testAccNetworkConnectionConfig(`
+  google {
+    name             = "peering"
+    peer_network_url = "projects/test/global/networks/default"
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccNetworkConnectionId, "status", "active"),
+					resource.TestCheckResourceAttr(testAccNetworkConnectionId, "wait_for_active", "true"),
+					resource.TestCheckResourceAttrSet(testAccNetworkConnectionId, "google.managed_network_url"),
+					resource.TestCheckNoResourceAttr(testAccNetworkConnectionId, "aws.peering.vpc_id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// acceptNetworkConnection accepts the peering on the fake peer side.
+func acceptNetworkConnection(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		return testFakeAPI.AcceptNetworkConnection(rs.Primary.ID)
+	}
+}
+
+// acceptPendingNetworkConnections keeps accepting connections on the fake peer side
+// until the test ends, so the provider waiting for them to become active can proceed.
+func acceptPendingNetworkConnections(t *testing.T) func() {
+	return func() {
+		done := make(chan struct{})
+		t.Cleanup(func() { close(done) })
+		go func() {
+			for {
+				testFakeAPI.AcceptPendingNetworkConnections()
+				select {
+				case <-done:
+					return
+				case <-time.After(50 * time.Millisecond):
+				}
+			}
+		}()
+	}
+}
+
+func testAccNetworkConnectionConfig(connection string) string {
+	return fmt.Sprintf(`
+resource "doublecloud_network" %[2]q {
+  project_id = %[1]q
+  name = %[2]q
+  region_id = "eu-central-1"
+  ipv4_cidr_block = "10.1.0.0/16"
+  cloud_type = "aws"
+}
+
+resource "doublecloud_network_connection" %[2]q {
+  network_id = doublecloud_network.%[2]s.id
+%[3]s
+}
+`, testProjectId, testAccNetworkConnectionName, connection)
+}
+
+func init() {
+	resource.AddTestSweepers("network_connection", &resource.Sweeper{
+		Name: "network_connection",
+		F:    sweepNetworkConnections,
+	})
+}
+
+func sweepNetworkConnections(_ string) error {
+	conf, err := configForSweepers()
+	if err != nil {
+		return err
+	}
+
+	networks := map[string]string{}
+	nit := conf.sdk.Network().Network().NetworkIterator(conf.ctx, &network.ListNetworksRequest{ProjectId: conf.ProjectId})
+	for nit.Next() {
+		networks[nit.Value().Id] = nit.Value().Name
+	}
+	if err := nit.Error(); err != nil {
+		return err
+	}
+
+	var errs error
+	rq := &network.ListNetworkConnectionsRequest{ProjectId: conf.ProjectId}
+	svc := conf.sdk.Network().NetworkConnection()
+	it := svc.NetworkConnectionIterator(conf.ctx, rq)
+
+	for it.Next() {
+		v := it.Value()
+		if strings.HasPrefix(networks[v.NetworkId], testPrefix) {
+			op, err := conf.sdk.WrapOperation(svc.Delete(conf.ctx, &network.DeleteNetworkConnectionRequest{NetworkConnectionId: v.Id}))
+			if err == nil {
+				err = op.Wait(conf.ctx)
+			}
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to sweep %v: %v", v.Id, err))
+			}
+		}
+	}
+	return errs
+}
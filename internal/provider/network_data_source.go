@@ -10,7 +10,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -23,6 +22,7 @@ func NewNetworkDataSource() datasource.DataSource {
 
 // NetworkDataSource defines the data source implementation.
 type NetworkDataSource struct {
+	config         *Config
 	sdk            *dcsdk.SDK
 	networkService *dcgennet.NetworkServiceClient
 }
@@ -48,7 +48,8 @@ func (d *NetworkDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 
 		Attributes: map[string]schema.Attribute{
 			"project_id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "Project identifier",
 			},
 			"id": schema.StringAttribute{
@@ -86,35 +87,21 @@ func (d *NetworkDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	sdk, ok := req.ProviderData.(*dcsdk.SDK)
+	config, ok := req.ProviderData.(*Config)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *dcsdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.sdk = sdk
+	d.config = config
+	d.sdk = config.sdk
 	d.networkService = d.sdk.Network().Network()
 }
 
-func (d *NetworkDataSource) getNetworkIdByName(ctx context.Context, m *NetworkDataSourceModel) diag.Diagnostics {
-	var diags diag.Diagnostics
-	it := d.networkService.NetworkIterator(ctx, &network.ListNetworksRequest{ProjectId: m.ProjectID.ValueString()})
-	for it.Next() {
-		n := it.Value()
-		if n.Name == m.Name.ValueString() {
-			m.Id = types.StringValue(n.Id)
-			return diags
-		}
-	}
-	diags.AddError("network not found", fmt.Sprintf("network with name `%v` haven't found", m.Name.ValueString()))
-
-	return diags
-}
-
 func (d *NetworkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data NetworkDataSourceModel
 
@@ -125,17 +112,25 @@ func (d *NetworkDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
+	projectId, diags := d.config.projectId(data.ProjectID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ProjectID = projectId
+
 	if data.Id == types.StringNull() {
-		diag := d.getNetworkIdByName(ctx, &data)
-		if diag.HasError() {
-			resp.Diagnostics.Append(diag...)
+		id, diags := lookupByName(ctx, "network", data.ProjectID.ValueString(), data.Name.ValueString(), d.config.networks)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+		data.Id = types.StringValue(id)
 	}
 
 	net, err := d.networkService.Get(ctx, &network.GetNetworkRequest{NetworkId: data.Id.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 		return
 	}
 
@@ -3,11 +3,22 @@ package provider
 import (
 	"context"
 	"fmt"
-
+	"net/netip"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -16,6 +27,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/v1"
 	dcsdk "github.com/doublecloud/go-sdk"
 	dcgennet "github.com/doublecloud/go-sdk/gen/network"
 )
@@ -23,24 +35,55 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NetworkResource{}
 var _ resource.ResourceWithImportState = &NetworkResource{}
+var _ resource.ResourceWithModifyPlan = &NetworkResource{}
+var _ resource.ResourceWithConfigValidators = &NetworkResource{}
+var _ resource.ResourceWithValidateConfig = &NetworkResource{}
 
 func NewNetworkResource() resource.Resource {
 	return &NetworkResource{}
 }
 
 type NetworkResource struct {
+	config         *Config
 	sdk            *dcsdk.SDK
 	networkService *dcgennet.NetworkServiceClient
 }
 
 type NetworkResourceModel struct {
-	Id            types.String `tfsdk:"id"`
-	ProjectID     types.String `tfsdk:"project_id"`
-	Name          types.String `tfsdk:"name"`
-	Description   types.String `tfsdk:"description"`
-	RegionID      types.String `tfsdk:"region_id"`
-	CloudType     types.String `tfsdk:"cloud_type"`
-	Ipv4CidrBlock types.String `tfsdk:"ipv4_cidr_block"`
+	Id            types.String     `tfsdk:"id"`
+	ProjectID     types.String     `tfsdk:"project_id"`
+	Name          types.String     `tfsdk:"name"`
+	Description   types.String     `tfsdk:"description"`
+	RegionID      types.String     `tfsdk:"region_id"`
+	CloudType     types.String     `tfsdk:"cloud_type"`
+	Ipv4CidrBlock types.String     `tfsdk:"ipv4_cidr_block"`
+	AWS           *networkAWSModel `tfsdk:"aws"`
+	Timeouts      timeouts.Value   `tfsdk:"timeouts"`
+}
+
+// networkAWSModel describes a customer VPC imported as a BYOC network.
+type networkAWSModel struct {
+	VpcId           types.String `tfsdk:"vpc_id"`
+	AccountId       types.String `tfsdk:"account_id"`
+	IamRoleArn      types.String `tfsdk:"iam_role_arn"`
+	SecurityGroupId types.String `tfsdk:"security_group_id"`
+	Subnets         types.List   `tfsdk:"subnets"`
+}
+
+type networkAWSSubnetModel struct {
+	Id     types.String `tfsdk:"id"`
+	ZoneId types.String `tfsdk:"zone_id"`
+}
+
+var networkAWSSubnetType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":      types.StringType,
+	"zone_id": types.StringType,
+}}
+
+var networkTimeouts = resourceTimeouts{
+	Create: 20 * time.Minute,
+	Read:   5 * time.Minute,
+	Delete: 20 * time.Minute,
 }
 
 func (r *NetworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,11 +104,9 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"project_id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "Project identifier",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"cloud_type": schema.StringAttribute{
 				Required:            true,
@@ -77,7 +118,7 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 			"name": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Name of network",
+				MarkdownDescription: "Name of network. Changing it replaces the network and all clusters in it",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -85,7 +126,7 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"description": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Description of network",
+				MarkdownDescription: "Description of network. Changing it replaces the network and all clusters in it",
 				Default:             stringdefault.StaticString(""),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -99,37 +140,294 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"ipv4_cidr_block": schema.StringAttribute{
-				Required: true,
-				// Optional: true,
-				MarkdownDescription: "The IPv4 network range for the subnet, in CIDR notation. For example, 10.0.0.0/16.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The IPv4 network range for the subnet, in CIDR notation. For example, 10.0.0.0/16. Imported networks use the range of the VPC. Must be a private range (10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16) with prefix length from /16 to /24 that doesn't overlap other networks of the project in the same region",
+				Validators:          []validator.String{networkCIDRValidator()},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"aws":      networkAWSSchemaBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Delete: true}),
+		},
+	}
+}
+
+func networkAWSSchemaBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Import an existing AWS VPC as a BYOC network instead of creating a DoubleCloud managed one. " +
+			"The IAM role is created by the DoubleCloud BYOC CloudFormation template or Terraform module",
+		Validators: []validator.Object{
+			objectvalidator.AlsoRequires(
+				path.MatchRelative().AtName("vpc_id"),
+				path.MatchRelative().AtName("account_id"),
+				path.MatchRelative().AtName("iam_role_arn"),
+			),
+		},
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"vpc_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the VPC",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^vpc-[0-9a-f]+$`), "must be a VPC ID like vpc-0123456789abcdef0"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the AWS account owning the VPC",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9]{12}$`), "must be a 12-digit AWS account ID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"iam_role_arn": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ARN of the IAM role DoubleCloud assumes to manage resources in the VPC",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^arn:aws:iam::[0-9]{12}:role/\S+$`), "must be an IAM role ARN like arn:aws:iam::123456789012:role/DoubleCloud"),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"security_group_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the security group DoubleCloud uses in the VPC",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subnets": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Subnets DoubleCloud uses in the VPC",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Subnet ID",
+						},
+						"zone_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Availability zone of the subnet",
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
 
+// Prefix lengths accepted for networks created by DoubleCloud.
+const (
+	networkMinPrefixLength = 16
+	networkMaxPrefixLength = 24
+)
+
+var privateIPv4Prefixes = []netip.Prefix{
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+}
+
+// networkCIDRValidator checks that the value is a private (RFC 1918) IPv4
+// network address with a prefix length DoubleCloud accepts.
+func networkCIDRValidator() validator.String {
+	return networkCIDR{minBits: networkMinPrefixLength, maxBits: networkMaxPrefixLength}
+}
+
+type networkCIDR struct {
+	minBits, maxBits int
+}
+
+func (v networkCIDR) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a private IPv4 CIDR block (10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16) with prefix length from /%d to /%d", v.minBits, v.maxBits)
+}
+
+func (v networkCIDR) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v networkCIDR) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	p, err := netip.ParsePrefix(value)
+	if err != nil || !p.Addr().Is4() {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid CIDR block", fmt.Sprintf("%q is not an IPv4 CIDR block like 10.0.0.0/16", value))
+		return
+	}
+	if p.Bits() < v.minBits || p.Bits() > v.maxBits {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid CIDR block", fmt.Sprintf("prefix length of %s must be from /%d to /%d", value, v.minBits, v.maxBits))
+		return
+	}
+	if p.Masked() != p {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid CIDR block", fmt.Sprintf("%s has host bits set, use the network address %s", value, p.Masked()))
+		return
+	}
+	for _, private := range privateIPv4Prefixes {
+		if private.Bits() <= p.Bits() && private.Contains(p.Addr()) {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "invalid CIDR block", fmt.Sprintf("%s is not a private range, use a block within 10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16", value))
+}
+
+func (r *NetworkResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("ipv4_cidr_block"), path.MatchRoot("aws")),
+	}
+}
+
+// ValidateConfig checks that the IAM outputs of the BYOC template belong to
+// the same AWS account as the imported VPC.
+func (r *NetworkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *NetworkResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.AWS == nil {
+		return
+	}
+
+	if !data.CloudType.IsUnknown() && data.CloudType.ValueString() != "aws" {
+		resp.Diagnostics.AddAttributeError(path.Root("cloud_type"), "invalid cloud type", "networks with aws block must have cloud_type aws")
+	}
+	account, arn := data.AWS.AccountId, data.AWS.IamRoleArn
+	if account.IsUnknown() || account.IsNull() || arn.IsUnknown() || arn.IsNull() {
+		return
+	}
+	if !strings.HasPrefix(arn.ValueString(), fmt.Sprintf("arn:aws:iam::%s:", account.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("aws").AtName("iam_role_arn"),
+			"IAM role from another account",
+			fmt.Sprintf("IAM role %s doesn't belong to account %s of the VPC", arn.ValueString(), account.ValueString()),
+		)
+	}
+}
+
 func (r *NetworkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	sdk, ok := req.ProviderData.(*dcsdk.SDK)
+	config, ok := req.ProviderData.(*Config)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *dcsdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.sdk = sdk
+	r.config = config
+	r.sdk = config.sdk
 	r.networkService = r.sdk.Network().Network()
 }
 
+func (r *NetworkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanProjectId(ctx, r.config, req, resp)
+	warnNetworkReplace(ctx, req, resp)
+	r.checkNetworkOverlap(ctx, req, resp)
+}
+
+// checkNetworkOverlap fails the plan of a new network if its CIDR block
+// overlaps another network of the project in the same region. The API rejects
+// such networks only after the apply has started.
+func (r *NetworkResource) checkNetworkOverlap(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.networkService == nil || resp.Diagnostics.HasError() {
+		return
+	}
+	var plan, state *NetworkResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, v := range []types.String{plan.Ipv4CidrBlock, plan.RegionID, plan.ProjectID} {
+		if v.IsNull() || v.IsUnknown() {
+			return
+		}
+	}
+	var id string
+	if state != nil {
+		if state.Ipv4CidrBlock.Equal(plan.Ipv4CidrBlock) && state.RegionID.Equal(plan.RegionID) && state.ProjectID.Equal(plan.ProjectID) {
+			return
+		}
+		// the network is replaced, so it doesn't overlap itself
+		id = state.Id.ValueString()
+	}
+	prefix, err := netip.ParsePrefix(plan.Ipv4CidrBlock.ValueString())
+	if err != nil {
+		// reported by the validator
+		return
+	}
+
+	it := r.networkService.NetworkIterator(ctx, &network.ListNetworksRequest{ProjectId: plan.ProjectID.ValueString()})
+	for it.Next() {
+		n := it.Value()
+		if n.Id == id || n.RegionId != plan.RegionID.ValueString() {
+			continue
+		}
+		other, err := netip.ParsePrefix(n.Ipv4CidrBlock)
+		if err != nil || !prefix.Overlaps(other) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ipv4_cidr_block"),
+			"network CIDR block overlaps",
+			fmt.Sprintf("%s overlaps %s of network %s (%s) in region %s. Networks of a project in the same region must not overlap, choose another CIDR block.",
+				prefix, n.Ipv4CidrBlock, n.Name, n.Id, n.RegionId),
+		)
+	}
+	if err := it.Error(); err != nil {
+		resp.Diagnostics.AddWarning("failed to check network CIDR block overlaps", err.Error())
+	}
+}
+
+// warnNetworkReplace explains why renaming a network is destructive:
+// the API can't update networks, so a new one is created and every cluster
+// referencing the old one is replaced with it.
+func warnNetworkReplace(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	for _, attr := range []string{"name", "description"} {
+		var prior, planned types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attr), &prior)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attr), &planned)...)
+		if planned.IsUnknown() || planned.Equal(prior) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root(attr),
+			"network will be replaced",
+			fmt.Sprintf("DoubleCloud networks can't be updated in place, changing %s destroys network %s and creates a new one. "+
+				"Clusters, transfers and other resources using this network are replaced as well. "+
+				"Revert the change to keep the network and everything in it.", attr, id.ValueString()),
+		)
+	}
+}
+
 func createNetworkRequest(m *NetworkResourceModel) (*network.CreateNetworkRequest, diag.Diagnostics) {
 	rq := &network.CreateNetworkRequest{}
 	rq.Name = m.Name.ValueString()
@@ -141,12 +439,58 @@ func createNetworkRequest(m *NetworkResourceModel) (*network.CreateNetworkReques
 	return rq, nil
 }
 
+func importNetworkRequest(m *NetworkResourceModel) *network.ImportNetworkRequest {
+	return &network.ImportNetworkRequest{
+		ProjectId:   m.ProjectID.ValueString(),
+		Name:        m.Name.ValueString(),
+		Description: m.Description.ValueString(),
+		Params: &network.ImportNetworkRequest_Aws{Aws: &network.ImportAWSVPCRequest{
+			VpcId:      m.AWS.VpcId.ValueString(),
+			RegionId:   m.RegionID.ValueString(),
+			AccountId:  m.AWS.AccountId.ValueString(),
+			IamRoleArn: m.AWS.IamRoleArn.ValueString(),
+		}},
+	}
+}
+
+func (m *NetworkResourceModel) parse(ctx context.Context, net *network.Network) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = types.StringValue(net.Id)
+	m.Name = types.StringValue(net.Name)
+	m.ProjectID = types.StringValue(net.ProjectId)
+	m.Description = types.StringValue(net.Description)
+	m.CloudType = types.StringValue(net.CloudType)
+	m.RegionID = types.StringValue(net.RegionId)
+	m.Ipv4CidrBlock = types.StringValue(net.Ipv4CidrBlock)
+
+	aws := net.GetAws()
+	if !net.IsExternal || aws == nil {
+		m.AWS = nil
+		return diags
+	}
+	if m.AWS == nil {
+		m.AWS = &networkAWSModel{}
+	}
+	m.AWS.VpcId = types.StringValue(aws.VpcId)
+	m.AWS.AccountId = types.StringValue(aws.GetAccountId().GetValue())
+	m.AWS.IamRoleArn = types.StringValue(aws.GetIamRoleArn().GetValue())
+	m.AWS.SecurityGroupId = types.StringValue(aws.SecurityGroupId)
+	subnets := make([]networkAWSSubnetModel, 0, len(aws.Subnets))
+	for _, s := range aws.Subnets {
+		subnets = append(subnets, networkAWSSubnetModel{Id: types.StringValue(s.Id), ZoneId: types.StringValue(s.ZoneId)})
+	}
+	m.AWS.Subnets, diags = types.ListValueFrom(ctx, networkAWSSubnetType, subnets)
+	return diags
+}
+
 func deleteNetworkRequest(m *NetworkResourceModel) (*network.DeleteNetworkRequest, diag.Diagnostics) {
 	rq := &network.DeleteNetworkRequest{NetworkId: m.Id.ValueString()}
 	return rq, nil
 }
 
 func (r *NetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.config.listings.invalidate("network")
+
 	var data *NetworkResourceModel
 
 	// Read Terraform plan data into the model
@@ -156,28 +500,64 @@ func (r *NetworkResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	net, err := r.networkService.Create(ctx, &network.CreateNetworkRequest{
-		Name:          data.Name.ValueString(),
-		CloudType:     data.CloudType.ValueString(),
-		ProjectId:     data.ProjectID.ValueString(),
-		Description:   data.Description.ValueString(),
-		RegionId:      data.RegionID.ValueString(),
-		Ipv4CidrBlock: data.Ipv4CidrBlock.ValueString(),
-	})
+	createTimeout, diags := data.Timeouts.Create(ctx, networkTimeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var net *doublecloud.Operation
+	var err error
+	if data.AWS != nil {
+		net, err = r.networkService.Import(ctx, importNetworkRequest(data))
+	} else {
+		net, err = r.networkService.Create(ctx, &network.CreateNetworkRequest{
+			Name:          data.Name.ValueString(),
+			CloudType:     data.CloudType.ValueString(),
+			ProjectId:     data.ProjectID.ValueString(),
+			Description:   data.Description.ValueString(),
+			RegionId:      data.RegionID.ValueString(),
+			Ipv4CidrBlock: data.Ipv4CidrBlock.ValueString(),
+		})
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(net, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
 	}
-	err = op.Wait(ctx)
+	data.Id = types.StringValue(op.ResourceId())
+
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		if op.ResourceId() != "" {
+			saveFailedCreate(ctx, resp, &data, err)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
 	}
 
-	data.Id = types.StringValue(op.ResourceId())
+	resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update computed fields, imported networks get CIDR and subnets from the VPC
+	rs, err := r.networkService.Get(ctx, &network.GetNetworkRequest{NetworkId: data.Id.ValueString()})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
+		return
+	}
+	resp.Diagnostics.Append(data.parse(ctx, rs)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -199,19 +579,30 @@ func (r *NetworkResource) Read(ctx context.Context, req resource.ReadRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, networkTimeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	net, err := r.networkService.Get(ctx, &network.GetNetworkRequest{NetworkId: data.Id.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get network", fmt.Sprintf("failed request, error: %v", err))
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("network %s not found, removing from state", data.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 		return
 	}
 
-	data.Id = types.StringValue(net.Id)
-	data.Name = types.StringValue(net.Name)
-	data.ProjectID = types.StringValue(net.ProjectId)
-	data.Description = types.StringValue(net.Description)
-	data.CloudType = types.StringValue(net.CloudType)
-	data.RegionID = types.StringValue(net.RegionId)
-	data.Ipv4CidrBlock = types.StringValue(net.Ipv4CidrBlock)
+	resp.Diagnostics.Append(data.parse(ctx, net)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	tflog.Info(ctx, fmt.Sprintf("read#5 %v", data.Id))
 
 	// Save updated data into Terraform state
@@ -219,6 +610,8 @@ func (r *NetworkResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *NetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.config.listings.invalidate("network")
+
 	var data *NetworkResourceModel
 
 	// Read Terraform plan data into the model
@@ -227,13 +620,15 @@ func (r *NetworkResource) Update(ctx context.Context, req resource.UpdateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.AddError("Failed to update network", "networks doesn't support updates")
+	// all network attributes require replacement, only timeouts can change in place
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *NetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.config.listings.invalidate("network")
+
 	var data *NetworkResourceModel
 
 	// Read Terraform prior state data into the model
@@ -243,16 +638,28 @@ func (r *NetworkResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, networkTimeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	net, err := r.networkService.Delete(ctx, &network.DeleteNetworkRequest{NetworkId: data.Id.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(net, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+		return
+	}
+	err = waitOperation(ctx, op)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
 	}
-	err = op.Wait(ctx)
 }
 
 func (r *NetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
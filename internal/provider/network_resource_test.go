@@ -1,12 +1,20 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
 	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
@@ -38,14 +46,196 @@ func TestAccNetworkResource(t *testing.T) {
 					resource.TestCheckResourceAttr(testAccNetworkId, "region_id", m.RegionID.ValueString()),
 					resource.TestCheckResourceAttr(testAccNetworkId, "ipv4_cidr_block", m.Ipv4CidrBlock.ValueString()),
 					resource.TestCheckResourceAttr(testAccNetworkId, "cloud_type", m.CloudType.ValueString()),
+					resource.TestCheckResourceAttr(testAccNetworkId, "timeouts.create", "30m"),
 				),
 			},
+			// Inherit project_id from provider configuration without replacement
+			{
+				Config: testAccNetworkResourceConfigDefaultProject(&m),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccNetworkId, "project_id", m.ProjectID.ValueString()),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      testAccNetworkId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			// Update not supported
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
 
+func TestAccNetworkResourceBYOC(t *testing.T) {
+	t.Parallel()
+	if testFakeAPI == nil {
+		t.Skip("BYOC needs a VPC and IAM role created by the DoubleCloud template")
+	}
+	name := fmt.Sprintf("%v-byoc-network", testPrefix)
+	id := fmt.Sprintf("doublecloud_network.%v", name)
+	aws := func(vpc, account, role string) string {
+		return fmt.Sprintf(`
+  aws {
+    vpc_id       = %q
+    account_id   = %q
+    iam_role_arn = %q
+  }`, vpc, account, role)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNetworkResourceBYOCConfig(name, `ipv4_cidr_block = "10.0.0.0/16"`+aws("vpc-0123456789abcdef0", "123456789012", "arn:aws:iam::123456789012:role/DoubleCloud")),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config:      testAccNetworkResourceBYOCConfig(name, aws("vpc-0123456789abcdef0", "123456789012", "arn:aws:iam::210987654321:role/DoubleCloud")),
+				ExpectError: regexp.MustCompile(`IAM role from another account`),
+			},
+			{
+				Config:      testAccNetworkResourceBYOCConfig(name, aws("0123456789abcdef0", "123456789012", "DoubleCloud")),
+				ExpectError: regexp.MustCompile(`must be a VPC ID(.|\n)*must be an IAM role ARN`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccNetworkResourceBYOCConfig(name, aws("vpc-0123456789abcdef0", "123456789012", "arn:aws:iam::123456789012:role/DoubleCloud")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(id, "ipv4_cidr_block", "172.31.0.0/16"),
+					resource.TestCheckResourceAttr(id, "aws.vpc_id", "vpc-0123456789abcdef0"),
+					resource.TestCheckResourceAttrSet(id, "aws.security_group_id"),
+					resource.TestCheckResourceAttr(id, "aws.subnets.#", "3"),
+					resource.TestCheckResourceAttr(id, "aws.subnets.0.zone_id", "eu-central-1a"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      id,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccNetworkResourceOverlap(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("%v-overlap-network", testPrefix)
+	networks := func(region, cidr string) string {
+		m := NetworkResourceModel{
+			ProjectID:     types.StringValue(testProjectId),
+			Name:          types.StringValue(name),
+			RegionID:      types.StringValue("eu-central-1"),
+			Ipv4CidrBlock: types.StringValue("10.2.0.0/16"),
+			CloudType:     types.StringValue("aws"),
+		}
+		config := testAccNetworkResourceConfig(&m)
+		if cidr != "" {
+			m.Name = types.StringValue(name + "-2")
+			m.RegionID = types.StringValue(region)
+			m.Ipv4CidrBlock = types.StringValue(cidr)
+			config += testAccNetworkResourceConfig(&m)
+		}
+		return config
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: networks("", ""),
+			},
+			{
+				Config:      networks("eu-central-1", "10.2.128.0/17"),
+				ExpectError: regexp.MustCompile(`10.2.128.0/17 overlaps 10.2.0.0/16 of network ` + name),
+			},
+			{
+				Config: networks("eu-west-1", "10.2.0.0/16"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fmt.Sprintf("doublecloud_network.%v-2", name), "ipv4_cidr_block", "10.2.0.0/16"),
+				),
+			},
+		},
+	})
+}
+
+func TestNetworkCIDRValidator(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		value string
+		err   string
+	}{
+		{value: "10.0.0.0/16"},
+		{value: "172.31.0.0/24"},
+		{value: "192.168.0.0/16"},
+		{value: "10.0.0.0", err: "is not an IPv4 CIDR block"},
+		{value: "fd00::/64", err: "is not an IPv4 CIDR block"},
+		{value: "10.0.0.0/8", err: "must be from /16 to /24"},
+		{value: "10.0.0.0/28", err: "must be from /16 to /24"},
+		{value: "10.0.0.1/16", err: "use the network address 10.0.0.0/16"},
+		{value: "172.42.0.0/16", err: "is not a private range"},
+		{value: "8.8.0.0/16", err: "is not a private range"},
+	} {
+		rs := validator.StringResponse{}
+		networkCIDRValidator().ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("ipv4_cidr_block"),
+			ConfigValue: types.StringValue(tc.value),
+		}, &rs)
+		switch {
+		case tc.err == "" && rs.Diagnostics.HasError():
+			t.Errorf("%s: unexpected error %v", tc.value, rs.Diagnostics)
+		case tc.err != "" && (rs.Diagnostics.ErrorsCount() != 1 || !strings.Contains(rs.Diagnostics[0].Detail(), tc.err)):
+			t.Errorf("%s: expected error %q, got %v", tc.value, tc.err, rs.Diagnostics)
+		}
+	}
+}
+
+func TestNetworkReplaceWarning(t *testing.T) {
+	ctx := context.Background()
+	r := NewNetworkResource()
+	var sch fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &sch)
+
+	network := func(name, description string) tfsdk.Plan {
+		p := tfsdk.Plan{Schema: sch.Schema, Raw: tftypes.NewValue(sch.Schema.Type().TerraformType(ctx), nil)}
+		for attr, v := range map[string]string{"id": "vpc123", "name": name, "description": description} {
+			if diags := p.SetAttribute(ctx, path.Root(attr), types.StringValue(v)); diags.HasError() {
+				t.Fatalf("failed to set %s: %v", attr, diags)
+			}
+		}
+		return p
+	}
+	modifyPlan := func(state, plan tfsdk.Plan) diag.Diagnostics {
+		req := fwresource.ModifyPlanRequest{State: tfsdk.State(state), Plan: plan}
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, req, resp)
+		return resp.Diagnostics
+	}
+
+	if diags := modifyPlan(network("net", ""), network("net", "")); len(diags) != 0 {
+		t.Errorf("expected no warnings for unchanged network, got %v", diags)
+	}
+	diags := modifyPlan(network("net", ""), network("renamed", "housekeeping"))
+	if diags.HasError() || diags.WarningsCount() != 2 {
+		t.Fatalf("expected a warning for name and description, got %v", diags)
+	}
+	for i, attr := range []string{"name", "description"} {
+		d := diags[i].(diag.DiagnosticWithPath)
+		if !d.Path().Equal(path.Root(attr)) || !strings.Contains(d.Detail(), "vpc123") {
+			t.Errorf("unexpected warning for %s: %v", attr, d)
+		}
+	}
+	// nothing to warn about on create
+	if diags := modifyPlan(tfsdk.Plan{Schema: sch.Schema, Raw: tftypes.NewValue(sch.Schema.Type().TerraformType(ctx), nil)}, network("net", "")); len(diags) != 0 {
+		t.Errorf("expected no warnings on create, got %v", diags)
+	}
+}
+
 func testAccNetworkResourceConfig(m *NetworkResourceModel) string {
 	return fmt.Sprintf(`
 resource "doublecloud_network" %[2]q {
@@ -54,6 +244,10 @@ resource "doublecloud_network" %[2]q {
   region_id = %[3]q
   ipv4_cidr_block = %[4]q
   cloud_type = %[5]q
+
+  timeouts {
+    create = "30m"
+  }
 }
 `, m.ProjectID.ValueString(),
 		m.Name.ValueString(),
@@ -62,11 +256,42 @@ resource "doublecloud_network" %[2]q {
 		m.CloudType.ValueString())
 }
 
+func testAccNetworkResourceConfigDefaultProject(m *NetworkResourceModel) string {
+	return fmt.Sprintf(`
+provider "doublecloud" {
+  project_id = %[1]q
+}
+
+resource "doublecloud_network" %[2]q {
+  name = %[2]q
+  region_id = %[3]q
+  ipv4_cidr_block = %[4]q
+  cloud_type = %[5]q
+}
+`, m.ProjectID.ValueString(),
+		m.Name.ValueString(),
+		m.RegionID.ValueString(),
+		m.Ipv4CidrBlock.ValueString(),
+		m.CloudType.ValueString())
+}
+
+func testAccNetworkResourceBYOCConfig(name, network string) string {
+	return fmt.Sprintf(`
+resource "doublecloud_network" %[2]q {
+  project_id = %[1]q
+  name = %[2]q
+  region_id = "eu-central-1"
+  cloud_type = "aws"
+%[3]s
+}
+`, testProjectId, name, network)
+}
+
 func init() {
 	resource.AddTestSweepers("network", &resource.Sweeper{
 		Name:         "network",
 		F:            sweepNetworks,
-		Dependencies: []string{},
+		Dependencies: []string{"network_connection"},
 	})
 }
 
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+	dcsdk "github.com/doublecloud/go-sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NetworksDataSource{}
+
+func NewNetworksDataSource() datasource.DataSource {
+	return &NetworksDataSource{}
+}
+
+type NetworksDataSource struct {
+	config *Config
+	sdk    *dcsdk.SDK
+}
+
+type NetworksDataSourceModel struct {
+	Id        types.String        `tfsdk:"id"`
+	ProjectID types.String        `tfsdk:"project_id"`
+	NameRegex types.String        `tfsdk:"name_regex"`
+	RegionID  types.String        `tfsdk:"region_id"`
+	CloudType types.String        `tfsdk:"cloud_type"`
+	Status    types.String        `tfsdk:"status"`
+	Networks  []networksItemModel `tfsdk:"networks"`
+}
+
+type networksItemModel struct {
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	RegionID      types.String `tfsdk:"region_id"`
+	CloudType     types.String `tfsdk:"cloud_type"`
+	Ipv4CidrBlock types.String `tfsdk:"ipv4_cidr_block"`
+	Status        types.String `tfsdk:"status"`
+}
+
+const networkStatusPrefix = "NETWORK_STATUS_"
+
+func (d *NetworksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_networks"
+}
+
+func (d *NetworksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := listFilterAttributes("network", enumNames(network.Network_NetworkStatus_name, networkStatusPrefix),
+		"name_regex", "region_id", "cloud_type", "status")
+	attrs["networks"] = schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "Networks matching the filters",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Network identifier",
+				},
+				"name": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Name of network",
+				},
+				"description": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Description of network",
+				},
+				"region_id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Region of network",
+				},
+				"cloud_type": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Cloud type (aws, gcp, azure)",
+				},
+				"ipv4_cidr_block": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The IPv4 network range for the subnet, in CIDR notation",
+				},
+				"status": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Status of network",
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Networks data source lists networks of the project",
+		Attributes:          attrs,
+	}
+}
+
+func (d *NetworksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*Config)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.config = config
+	d.sdk = config.sdk
+}
+
+func (d *NetworksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NetworksDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectId, diags := d.config.projectId(data.ProjectID)
+	resp.Diagnostics.Append(diags...)
+	nameRegex, diags := compileNameRegex(data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = projectId
+	data.ProjectID = projectId
+
+	data.Networks = []networksItemModel{}
+	items, err := d.config.networks(ctx, projectId.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to list", err)...)
+		return
+	}
+	for _, n := range items {
+		status := enumName(n.Status.String(), networkStatusPrefix)
+		if !matchNameRegex(nameRegex, n.Name) ||
+			!matchFilter(data.RegionID, n.RegionId) ||
+			!matchFilter(data.CloudType, n.CloudType) ||
+			!matchFilter(data.Status, status) {
+			continue
+		}
+		data.Networks = append(data.Networks, networksItemModel{
+			Id:            types.StringValue(n.Id),
+			Name:          types.StringValue(n.Name),
+			Description:   types.StringValue(n.Description),
+			RegionID:      types.StringValue(n.RegionId),
+			CloudType:     types.StringValue(n.CloudType),
+			Ipv4CidrBlock: types.StringValue(n.Ipv4CidrBlock),
+			Status:        types.StringValue(status),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNetworksDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNetworksDataSourceConfig("["),
+				ExpectError: regexp.MustCompile(`invalid regular expression`),
+			},
+			// Read testing
+			{
+				Config: testAccNetworksDataSourceConfig(fmt.Sprintf("^%v$", testNetworkName)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.doublecloud_networks.test", "networks.#", "1"),
+					resource.TestCheckResourceAttr("data.doublecloud_networks.test", "networks.0.name", testNetworkName),
+					resource.TestCheckResourceAttr("data.doublecloud_networks.test", "networks.0.region_id", "eu-central-1"),
+					resource.TestCheckResourceAttr("data.doublecloud_networks.test", "networks.0.cloud_type", "aws"),
+					resource.TestCheckResourceAttr("data.doublecloud_networks.test", "networks.0.ipv4_cidr_block", "172.42.0.0/16"),
+					resource.TestCheckResourceAttr("data.doublecloud_networks.test", "networks.0.status", "active"),
+					resource.TestCheckResourceAttr("data.doublecloud_networks.other_region", "networks.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNetworksDataSourceConfig(nameRegex string) string {
+	return fmt.Sprintf(`
+data "doublecloud_networks" "test" {
+	project_id = %[1]q
+	name_regex = %[2]q
+	region_id = "eu-central-1"
+	cloud_type = "aws"
+	status = "active"
+}
+
+data "doublecloud_networks" "other_region" {
+	project_id = %[1]q
+	name_regex = %[2]q
+	region_id = "us-east-1"
+}`, testProjectId, nameRegex)
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/doublecloud/go-genproto/doublecloud/v1"
+	"github.com/doublecloud/go-sdk/operation"
+)
+
+// pendingOperationKey is a private state key of an operation that was still running
+// when an apply was interrupted.
+const pendingOperationKey = "pending_operation"
+
+type pendingOperation struct {
+	Id string `json:"id,omitempty"`
+}
+
+// privateState is implemented by resource private state in requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+type wrapOperationFunc func(*doublecloud.Operation, error) (*operation.Operation, error)
+
+func setPendingOperation(ctx context.Context, private privateState, id string) diag.Diagnostics {
+	value, err := json.Marshal(pendingOperation{Id: id})
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("failed to save pending operation", err.Error())}
+	}
+	return private.SetKey(ctx, pendingOperationKey, value)
+}
+
+func loadPendingOperation(ctx context.Context, wrap wrapOperationFunc, private privateState) (*operation.Operation, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, pendingOperationKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+	var p pendingOperation
+	if err := json.Unmarshal(value, &p); err != nil {
+		diags.AddError("failed to load pending operation", err.Error())
+		return nil, diags
+	}
+	if p.Id == "" {
+		return nil, diags
+	}
+	op, err := wrap(&doublecloud.Operation{Id: p.Id, Status: doublecloud.Operation_STATUS_RUNNING}, nil)
+	if err != nil {
+		diags.AddError("failed to load pending operation", err.Error())
+		return nil, diags
+	}
+	return op, diags
+}
+
+// pollPendingOperation checks the operation left by an interrupted apply
+// and forgets it once it has finished.
+func pollPendingOperation(ctx context.Context, wrap wrapOperationFunc, private privateState) diag.Diagnostics {
+	op, diags := loadPendingOperation(ctx, wrap, private)
+	if op == nil {
+		return diags
+	}
+	if err := op.Poll(ctx); err != nil {
+		if isNotFoundError(err) {
+			// operations aren't kept forever, the resource itself is checked by the caller
+			diags.Append(setPendingOperation(ctx, private, "")...)
+			return diags
+		}
+		diags.Append(apiErrorDiagnostics("failed to get pending operation", err)...)
+		return diags
+	}
+	if !op.Done() {
+		tflog.Info(ctx, fmt.Sprintf("operation %s is still running", op.Id()))
+		return diags
+	}
+	if op.Failed() {
+		diags.AddWarning("pending operation failed", fmt.Sprintf("operation %s failed: %v", op.Id(), op.Error()))
+	}
+	diags.Append(setPendingOperation(ctx, private, "")...)
+	return diags
+}
+
+// waitPendingOperation waits for the operation left by an interrupted apply,
+// so the resource isn't changed while it is still being created.
+func waitPendingOperation(ctx context.Context, wrap wrapOperationFunc, private privateState) diag.Diagnostics {
+	op, diags := loadPendingOperation(ctx, wrap, private)
+	if op == nil {
+		return diags
+	}
+	tflog.Info(ctx, fmt.Sprintf("resuming wait for operation %s", op.Id()))
+	err := waitOperation(ctx, op)
+	if err != nil && !op.Failed() {
+		diags.Append(apiErrorDiagnostics("failed to wait for pending operation", err)...)
+		return diags
+	}
+	if err != nil {
+		diags.AddWarning("pending operation failed", err.Error())
+	}
+	diags.Append(setPendingOperation(ctx, private, "")...)
+	return diags
+}
+
+// saveInterruptedCreate keeps a resource in state once its creation operation has a resource ID.
+// An apply interrupted while the operation is still running produces only a warning, so the
+// resource isn't tainted and the next run resumes the operation. Failed operations and timeouts
+// are reported as errors and Terraform taints the resource.
+func saveInterruptedCreate(ctx context.Context, resp *resource.CreateResponse, op *operation.Operation, data any, err error) {
+	if ctx.Err() != context.Canceled || op.Done() {
+		saveFailedCreate(ctx, resp, data, err)
+		return
+	}
+	resp.Diagnostics.Append(setPendingOperation(ctx, resp.Private, op.Id())...)
+	resp.Diagnostics.Append(setPartialState(ctx, &resp.State, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.AddWarning("creation is still in progress", err.Error()+
+		"\n\nThe resource was saved to state, the next plan or apply will check the pending operation instead of creating the resource again.")
+}
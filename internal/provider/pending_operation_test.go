@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/doublecloud/go-genproto/doublecloud/v1"
+	"github.com/doublecloud/go-sdk/operation"
+)
+
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p fakePrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func fakeWrapOperation(state *doublecloud.Operation) wrapOperationFunc {
+	return func(op *doublecloud.Operation, err error) (*operation.Operation, error) {
+		return operation.New(&fakeClickhouseOperations{op: state}, op), err
+	}
+}
+
+func TestPendingOperation(t *testing.T) {
+	ctx := context.Background()
+	running := &doublecloud.Operation{Id: "chopending", Status: doublecloud.Operation_STATUS_RUNNING}
+	done := &doublecloud.Operation{Id: "chopending", Status: doublecloud.Operation_STATUS_DONE}
+
+	private := fakePrivateState{}
+	if diags := setPendingOperation(ctx, private, "chopending"); diags.HasError() {
+		t.Fatalf("failed to save pending operation: %v", diags)
+	}
+
+	if diags := pollPendingOperation(ctx, fakeWrapOperation(running), private); diags.HasError() {
+		t.Fatalf("failed to poll pending operation: %v", diags)
+	}
+	op, _ := loadPendingOperation(ctx, fakeWrapOperation(running), private)
+	if op == nil || op.Id() != "chopending" {
+		t.Fatalf("running operation should be kept, got %v", op)
+	}
+
+	if diags := waitPendingOperation(ctx, fakeWrapOperation(done), private); diags.HasError() {
+		t.Fatalf("failed to wait for pending operation: %v", diags)
+	}
+	op, _ = loadPendingOperation(ctx, fakeWrapOperation(done), private)
+	if op != nil {
+		t.Fatalf("finished operation should be forgotten, got %s", op.Id())
+	}
+}
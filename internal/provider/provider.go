@@ -3,14 +3,26 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/doublecloud/go-sdk/iamkey"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc"
 
 	dc "github.com/doublecloud/go-sdk"
 )
@@ -27,30 +39,120 @@ type DoubleCloudProvider struct {
 
 // DoubleCloudProviderModel describes the provider data model.
 type DoubleCloudProviderModel struct {
-	AuthorizedKey types.String `tfsdk:"authorized_key"`
+	AuthorizedKey     types.String `tfsdk:"authorized_key"`
+	AuthorizedKeyFile types.String `tfsdk:"authorized_key_file"`
+	AuthorizedKeyJSON types.String `tfsdk:"authorized_key_json"`
+	Token             types.String `tfsdk:"token"`
+	ProjectId         types.String `tfsdk:"project_id"`
+	Endpoint          types.String `tfsdk:"endpoint"`
+	Plaintext         types.Bool   `tfsdk:"plaintext"`
+	CABundleFile      types.String `tfsdk:"ca_bundle_file"`
+	HTTPSProxy        types.String `tfsdk:"https_proxy"`
+	MaxRetries        types.Int64  `tfsdk:"max_retries"`
+	RetryMaxInterval  types.String `tfsdk:"retry_max_interval"`
+
+	MaxConcurrentRequests           types.Int64 `tfsdk:"max_concurrent_requests"`
+	MaxConcurrentRequestsPerService types.Map   `tfsdk:"max_concurrent_requests_per_service"`
 }
 
 type Config struct {
 	Credentials *dc.Credentials
 	ProjectId   string
 
+	// Endpoint overrides address of every API service, host:port.
+	Endpoint     string
+	Plaintext    bool
+	CABundleFile string
+	HTTPSProxy   string
+
+	// MaxRetries limits repeats of API calls failed with transient errors.
+	MaxRetries       int
+	RetryMaxInterval time.Duration
+
+	// MaxConcurrentRequests limits API calls running at the same time, 0 means unlimited.
+	MaxConcurrentRequests           int
+	MaxConcurrentRequestsPerService map[string]int
+
 	ctx context.Context
 
-	sdk *dc.SDK
+	sdk      *dc.SDK
+	listings *listingCache
 }
 
 func (c *Config) init(ctx context.Context) error {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return err
+	}
+	opts, err := c.dialOptions()
+	if err != nil {
+		return err
+	}
+	limiter := newRequestLimiter(c.MaxConcurrentRequests, c.MaxConcurrentRequestsPerService)
+	// every retry attempt takes its own slot, so backoff doesn't hold the limit
+	opts = append(opts, grpc.WithChainUnaryInterceptor(c.retryInterceptor(), limiter.interceptor()))
 	sdk, err := dc.Build(ctx, dc.Config{
 		Credentials: *c.Credentials,
-	})
+		Endpoint:    c.Endpoint,
+		Plaintext:   c.Plaintext,
+		TLSConfig:   tlsConfig,
+	}, opts...)
 	if err != nil {
 		return err
 	}
 	c.sdk = sdk
 	c.ctx = ctx
+	c.listings = newListingCache()
 	return nil
 }
 
+// projectId returns the given project identifier or falls back to the
+// provider-level default when it is not set.
+func (c *Config) projectId(v types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !v.IsNull() {
+		return v, diags
+	}
+	if c.ProjectId == "" {
+		diags.AddAttributeError(path.Root("project_id"), "missing project_id", "specify project_id in the resource or in the provider configuration")
+		return v, diags
+	}
+	return types.StringValue(c.ProjectId), diags
+}
+
+// modifyPlanProjectId fills project_id of the planned resource from the
+// provider configuration and forces replacement when the resolved
+// project differs from the one in the state.
+func modifyPlanProjectId(ctx context.Context, c *Config, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or if the provider hasn't been configured yet.
+	if req.Plan.Raw.IsNull() || c == nil {
+		return
+	}
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("project_id"), &configured)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectId, diags := c.projectId(configured)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("project_id"), projectId)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var prior types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("project_id"), &prior)...)
+	// Imported resources may lack project_id in the state, keep them as is.
+	if !prior.IsNull() && !prior.Equal(projectId) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("project_id"))
+	}
+}
+
 func (p *DoubleCloudProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "doublecloud"
 	resp.Version = p.version
@@ -58,35 +160,231 @@ func (p *DoubleCloudProvider) Metadata(ctx context.Context, req provider.Metadat
 
 func (p *DoubleCloudProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Credentials are taken from the first defined source: `token`, `authorized_key_json`, `authorized_key_file`, `authorized_key` attributes, then DC_TOKEN, DC_AUTHKEY_JSON, DC_AUTHKEY environment variables.",
 		Attributes: map[string]schema.Attribute{
 			"authorized_key": schema.StringAttribute{
-				MarkdownDescription: "Path to authorized key",
+				MarkdownDescription: "Contents of authorized key (json encoded)",
+				Optional:            true,
+				Sensitive:           true,
+				DeprecationMessage:  "Use authorized_key_json or authorized_key_file instead",
+				Validators:          []validator.String{credentialsConflictValidator("authorized_key")},
+			},
+			"authorized_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to authorized key. May also be provided via DC_AUTHKEY environment variable.",
+				Optional:            true,
+				Validators:          []validator.String{credentialsConflictValidator("authorized_key_file")},
+			},
+			"authorized_key_json": schema.StringAttribute{
+				MarkdownDescription: "Contents of authorized key (json encoded). May also be provided via DC_AUTHKEY_JSON environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{credentialsConflictValidator("authorized_key_json")},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Pre-issued IAM token. May also be provided via DC_TOKEN environment variable.",
 				Optional:            true,
 				Sensitive:           true,
+				Validators:          []validator.String{credentialsConflictValidator("token")},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Default project identifier for resources and data sources. May also be provided via DC_PROJECT_ID environment variable.",
+				Optional:            true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "API endpoint (`host:port`) used for all services instead of `*.api.double.cloud:443`. May also be provided via DC_ENDPOINT environment variable.",
+				Optional:            true,
+			},
+			"plaintext": schema.BoolAttribute{
+				MarkdownDescription: "Connect to the API endpoint without TLS. May also be provided via DC_PLAINTEXT environment variable.",
+				Optional:            true,
+			},
+			"ca_bundle_file": schema.StringAttribute{
+				MarkdownDescription: "Path to PEM encoded CA certificates used to verify the API endpoint. May also be provided via DC_CA_BUNDLE_FILE environment variable.",
+				Optional:            true,
+			},
+			"https_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy used to connect to the API endpoint. May also be provided via DC_HTTPS_PROXY or HTTPS_PROXY environment variables.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of retries of API calls failed with transient errors, 0 disables retries. Defaults to %d.", defaultMaxRetries),
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"retry_max_interval": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Maximum delay between retries, e.g. `10s`. Delays grow exponentially up to this value. Defaults to `%s`.", defaultRetryMaxInterval),
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of API calls running at the same time, 0 means unlimited. Defaults to %d.", defaultMaxConcurrentRequests),
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"max_concurrent_requests_per_service": schema.MapAttribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of API calls running at the same time per service, in addition to `max_concurrent_requests`. Keys are services: %s.", strings.Join(apiServices, ", ")),
+				Optional:            true,
+				ElementType:         types.Int64Type,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(apiServices...)),
+					mapvalidator.ValueInt64sAre(int64validator.AtLeast(1)),
+				},
 			},
 		},
 	}
 }
-func configureCredentials(data *DoubleCloudProviderModel) (dc.Credentials, error) {
-	var key *iamkey.Key
-	var err error
 
-	envKey := os.Getenv("DC_AUTHKEY")
-
-	if data.AuthorizedKey.IsNull() && envKey == "" {
-		return nil, errors.New("Please specify one of auth methods for Double.Cloud")
+// credentialsConflictValidator forbids setting more than one credentials
+// attribute in the provider configuration.
+func credentialsConflictValidator(name string) validator.String {
+	var others []path.Expression
+	for _, v := range []string{"authorized_key", "authorized_key_file", "authorized_key_json", "token"} {
+		if v != name {
+			others = append(others, path.MatchRoot(v))
+		}
 	}
-	if envKey != "" {
-		key, err = iamkey.ReadFromJSONFile(envKey)
-	} else {
-		key, err = iamkey.ReadFromJSONBytes([]byte(data.AuthorizedKey.ValueString()))
+	return stringvalidator.ConflictsWith(others...)
+}
+
+type credentialSource struct {
+	// attribute or environment variable name
+	name      string
+	attribute bool
+	value     string
+	build     func(string) (dc.Credentials, error)
+}
+
+func tokenCredentials(token string) (dc.Credentials, error) {
+	return dc.NewIAMTokenCredentials(token), nil
+}
+
+func authorizedKeyFileCredentials(filename string) (dc.Credentials, error) {
+	key, err := iamkey.ReadFromJSONFile(filename)
+	if err != nil {
+		return nil, err
 	}
+	return dc.ServiceAccountKey(key)
+}
+
+func authorizedKeyJSONCredentials(data string) (dc.Credentials, error) {
+	key, err := iamkey.ReadFromJSONBytes([]byte(data))
 	if err != nil {
 		return nil, err
 	}
 	return dc.ServiceAccountKey(key)
 }
 
+// configureCredentials uses the first defined source of credentials.
+// Provider attributes take precedence over environment variables:
+// token, authorized_key_json, authorized_key_file, authorized_key (deprecated),
+// then DC_TOKEN, DC_AUTHKEY_JSON, DC_AUTHKEY.
+func configureCredentials(data *DoubleCloudProviderModel) (dc.Credentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	sources := []credentialSource{
+		{name: "token", attribute: true, value: data.Token.ValueString(), build: tokenCredentials},
+		{name: "authorized_key_json", attribute: true, value: data.AuthorizedKeyJSON.ValueString(), build: authorizedKeyJSONCredentials},
+		{name: "authorized_key_file", attribute: true, value: data.AuthorizedKeyFile.ValueString(), build: authorizedKeyFileCredentials},
+		{name: "authorized_key", attribute: true, value: data.AuthorizedKey.ValueString(), build: authorizedKeyJSONCredentials},
+		{name: "DC_TOKEN", value: os.Getenv("DC_TOKEN"), build: tokenCredentials},
+		{name: "DC_AUTHKEY_JSON", value: os.Getenv("DC_AUTHKEY_JSON"), build: authorizedKeyJSONCredentials},
+		{name: "DC_AUTHKEY", value: os.Getenv("DC_AUTHKEY"), build: authorizedKeyFileCredentials},
+	}
+	for _, src := range sources {
+		if src.value == "" {
+			continue
+		}
+		creds, err := src.build(src.value)
+		if err != nil {
+			if src.attribute {
+				diags.AddAttributeError(path.Root(src.name), "failed to use credentials", fmt.Sprintf("invalid credentials in %s: %v", src.name, err))
+			} else {
+				diags.AddError("failed to use credentials", fmt.Sprintf("invalid credentials in %s environment variable: %v", src.name, err))
+			}
+			return nil, diags
+		}
+		return creds, diags
+	}
+
+	diags.AddError("failed to use credentials", "Please specify one of auth methods for Double.Cloud: token, authorized_key_json, authorized_key_file attributes or DC_TOKEN, DC_AUTHKEY_JSON, DC_AUTHKEY environment variables")
+	return nil, diags
+}
+
+func configureProjectId(data *DoubleCloudProviderModel) string {
+	return stringFromEnv(data.ProjectId, "DC_PROJECT_ID")
+}
+
+func configureTransport(data *DoubleCloudProviderModel, conf *Config) error {
+	conf.Endpoint = stringFromEnv(data.Endpoint, "DC_ENDPOINT")
+	conf.CABundleFile = stringFromEnv(data.CABundleFile, "DC_CA_BUNDLE_FILE")
+	conf.HTTPSProxy = stringFromEnv(data.HTTPSProxy, "DC_HTTPS_PROXY", "HTTPS_PROXY", "https_proxy")
+
+	if v := data.Plaintext; !v.IsNull() {
+		conf.Plaintext = v.ValueBool()
+	} else if v := os.Getenv("DC_PLAINTEXT"); v != "" {
+		plaintext, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("failed to parse DC_PLAINTEXT: %w", err)
+		}
+		conf.Plaintext = plaintext
+	}
+
+	if conf.Plaintext && conf.CABundleFile != "" {
+		return errors.New("ca_bundle_file can't be used together with plaintext")
+	}
+	return nil
+}
+
+func configureLimits(ctx context.Context, data *DoubleCloudProviderModel, conf *Config) diag.Diagnostics {
+	conf.MaxConcurrentRequests = defaultMaxConcurrentRequests
+	if !data.MaxConcurrentRequests.IsNull() {
+		conf.MaxConcurrentRequests = int(data.MaxConcurrentRequests.ValueInt64())
+	}
+	var perService map[string]int64
+	diags := data.MaxConcurrentRequestsPerService.ElementsAs(ctx, &perService, false)
+	conf.MaxConcurrentRequestsPerService = make(map[string]int, len(perService))
+	for service, limit := range perService {
+		conf.MaxConcurrentRequestsPerService[service] = int(limit)
+	}
+	tflog.Debug(ctx, "API concurrency limits", map[string]interface{}{
+		"max_concurrent_requests":             conf.MaxConcurrentRequests,
+		"max_concurrent_requests_per_service": conf.MaxConcurrentRequestsPerService,
+	})
+	return diags
+}
+
+func configureRetries(data *DoubleCloudProviderModel, conf *Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conf.MaxRetries = defaultMaxRetries
+	if !data.MaxRetries.IsNull() {
+		conf.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	conf.RetryMaxInterval = defaultRetryMaxInterval
+	if !data.RetryMaxInterval.IsNull() {
+		d, err := time.ParseDuration(data.RetryMaxInterval.ValueString())
+		if err != nil || d <= 0 {
+			diags.AddAttributeError(path.Root("retry_max_interval"), "invalid retry_max_interval", fmt.Sprintf("expected positive duration like 10s, got %q", data.RetryMaxInterval.ValueString()))
+			return diags
+		}
+		conf.RetryMaxInterval = d
+	}
+	return diags
+}
+
+// stringFromEnv returns the attribute value or the first non-empty
+// environment variable when the attribute is not set.
+func stringFromEnv(v types.String, envs ...string) string {
+	if !v.IsNull() {
+		return v.ValueString()
+	}
+	for _, env := range envs {
+		if e := os.Getenv(env); e != "" {
+			return e
+		}
+	}
+	return ""
+}
+
 func (p *DoubleCloudProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data DoubleCloudProviderModel
 
@@ -96,25 +394,35 @@ func (p *DoubleCloudProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	creds, err := configureCredentials(&data)
+	creds, diags := configureCredentials(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	conf := &Config{Credentials: &creds, ProjectId: configureProjectId(&data)}
+	err := configureTransport(&data, conf)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to use credentials", err.Error())
+		resp.Diagnostics.AddError("failed to configure transport", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(configureRetries(&data, conf)...)
+	resp.Diagnostics.Append(configureLimits(ctx, &data, conf)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	conf := &Config{Credentials: &creds}
 	err = conf.init(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("failed to init client", err.Error())
 	}
 
-	// TODO: forward conf struct instead of sdk
-	resp.DataSourceData = conf.sdk
-	resp.ResourceData = conf.sdk
+	resp.DataSourceData = conf
+	resp.ResourceData = conf
 }
 
 func (p *DoubleCloudProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewNetworkResource,
+		NewNetworkConnectionResource,
 		NewWorkbookResource,
 		NewKafkaClusterResource,
 		NewTransferResource,
@@ -131,6 +439,10 @@ func (p *DoubleCloudProvider) DataSources(ctx context.Context) []func() datasour
 		NewTransferDataSource,
 		// NewTransferEndpointDataSource,
 		NewClickhouseDataSource,
+		NewNetworksDataSource,
+		NewClickhouseClustersDataSource,
+		NewKafkaClustersDataSource,
+		NewTransfersDataSource,
 	}
 }
 
@@ -4,12 +4,19 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/doublecloud/go-genproto/doublecloud/clickhouse/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/kafka/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/network/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1"
 	dc "github.com/doublecloud/go-sdk"
-	"github.com/doublecloud/go-sdk/iamkey"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/doublecloud/terraform-provider-doublecloud/internal/fakedc"
 )
 
 const envProjectId = "DC_PROJECT_ID"
@@ -44,7 +51,10 @@ func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
-	requiredEnvs := []string{envAuthkey, envProjectId, envNetworkId, envClickhouseName, envKafkaName, envNetworkName, envTransferName}
+	requiredEnvs := []string{envProjectId, envNetworkId, envClickhouseName, envKafkaName, envNetworkName, envTransferName}
+	if testFakeAPI == nil {
+		requiredEnvs = append(requiredEnvs, envAuthkey)
+	}
 	for _, envName := range requiredEnvs {
 		if os.Getenv(envName) == "" {
 			t.Fatalf("%s must be set for acceptance tests", envName)
@@ -52,21 +62,104 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
-func configForSweepers() (*Config, error) {
-	config := &Config{}
+// testFakeAPI serves DoubleCloud API in-process when no credentials or
+// endpoint are given, so tests run without an account.
+var testFakeAPI *fakedc.Server
 
-	if v := os.Getenv(envAuthkey); v != "" {
-		key, err := iamkey.ReadFromJSONFile(v)
-		if err != nil {
-			return nil, err
+// setupFakeAPI starts testFakeAPI with resources for data source tests and
+// points the provider to it through environment.
+func setupFakeAPI() error {
+	for _, env := range []string{"DC_TOKEN", "DC_AUTHKEY_JSON", envAuthkey, "DC_ENDPOINT"} {
+		if os.Getenv(env) != "" {
+			return nil
 		}
-		credentials, err := dc.ServiceAccountKey(key)
-		if err != nil {
-			return nil, err
+	}
+
+	srv := fakedc.New()
+	if err := srv.Start(); err != nil {
+		return err
+	}
+	projectId := "fake-project"
+	networkId := srv.AddNetwork(&network.Network{
+		ProjectId:     projectId,
+		Name:          "fake-network",
+		CloudType:     "aws",
+		RegionId:      "eu-central-1",
+		Ipv4CidrBlock: "172.42.0.0/16",
+	})
+	srv.AddClickhouseCluster(&clickhouse.Cluster{
+		ProjectId: projectId,
+		Name:      "fake-clickhouse",
+		CloudType: "aws",
+		RegionId:  "eu-central-1",
+		Version:   "23.8",
+		NetworkId: networkId,
+	})
+	srv.AddKafkaCluster(&kafka.Cluster{
+		ProjectId: projectId,
+		Name:      "fake-kafka",
+		CloudType: "aws",
+		RegionId:  "eu-central-1",
+		Version:   "3.3",
+		NetworkId: networkId,
+	})
+	source := &transfer.Endpoint{ProjectId: projectId, Name: "fake-source"}
+	source.Id = srv.AddEndpoint(source)
+	target := &transfer.Endpoint{ProjectId: projectId, Name: "fake-target"}
+	target.Id = srv.AddEndpoint(target)
+	srv.AddTransfer(&transfer.Transfer{
+		ProjectId: projectId,
+		Name:      "fake-transfer",
+		Source:    source,
+		Target:    target,
+		Status:    transfer.TransferStatus_RUNNING,
+		Type:      transfer.TransferType_INCREMENT_ONLY,
+	})
+
+	envs := map[string]string{
+		"DC_ENDPOINT":     srv.Addr(),
+		"DC_PLAINTEXT":    "true",
+		"DC_TOKEN":        "fake-token",
+		envProjectId:      projectId,
+		envNetworkId:      networkId,
+		envClickhouseName: "fake-clickhouse",
+		envKafkaName:      "fake-kafka",
+		envNetworkName:    "fake-network",
+		envTransferName:   "fake-transfer",
+	}
+	for k, v := range envs {
+		if err := os.Setenv(k, v); err != nil {
+			return err
 		}
-		config.Credentials = &credentials
-	} else {
-		return nil, fmt.Errorf("%s must be set for sweep", envAuthkey)
+	}
+	testProjectId = projectId
+	testNetworkId = networkId
+	testClickhouseName = envs[envClickhouseName]
+	testKafkaName = envs[envKafkaName]
+	testNetworkName = envs[envNetworkName]
+	testDSTransferName = envs[envTransferName]
+	testFakeAPI = srv
+	return nil
+}
+
+func configForSweepers() (*Config, error) {
+	config := &Config{}
+	// sweepers have no provider configuration, settings come from the environment
+	data := &DoubleCloudProviderModel{MaxConcurrentRequestsPerService: types.MapNull(types.Int64Type)}
+
+	credentials, diags := configureCredentials(data)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to configure credentials for sweep: %v", diags)
+	}
+	config.Credentials = &credentials
+	if err := configureTransport(data, config); err != nil {
+		return nil, fmt.Errorf("failed to configure transport for sweep: %w", err)
+	}
+	if diags := configureLimits(context.Background(), data, config); diags.HasError() {
+		return nil, fmt.Errorf("failed to configure limits for sweep: %v", diags)
+	}
+	if diags := configureRetries(data, config); diags.HasError() {
+		return nil, fmt.Errorf("failed to configure retries for sweep: %v", diags)
 	}
 	config.ProjectId = os.Getenv(envProjectId)
 	if config.ProjectId == "" {
@@ -76,3 +169,58 @@ func configForSweepers() (*Config, error) {
 	err := config.init(context.Background())
 	return config, err
 }
+
+func TestConfigForSweepers(t *testing.T) {
+	t.Setenv("DC_TOKEN", "sweep-token")
+	t.Setenv("DC_ENDPOINT", "localhost:4443")
+	t.Setenv("DC_PLAINTEXT", "true")
+	t.Setenv(envProjectId, "sweep-project")
+
+	config, err := configForSweepers()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Endpoint != "localhost:4443" || !config.Plaintext {
+		t.Errorf("expected sweepers to use transport from environment, got endpoint %q, plaintext %v", config.Endpoint, config.Plaintext)
+	}
+	if config.MaxConcurrentRequests != defaultMaxConcurrentRequests || config.MaxRetries != defaultMaxRetries {
+		t.Errorf("expected default limits and retries, got %d and %d", config.MaxConcurrentRequests, config.MaxRetries)
+	}
+}
+
+func TestConfigureCredentials(t *testing.T) {
+	t.Setenv("DC_TOKEN", "env-token")
+	t.Setenv("DC_AUTHKEY_JSON", "")
+	t.Setenv(envAuthkey, "testdata/missing.json")
+
+	// Attribute takes precedence over environment
+	creds, diags := configureCredentials(&DoubleCloudProviderModel{Token: types.StringValue("attr-token")})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	token, err := creds.(dc.NonExchangeableCredentials).IAMToken(context.Background())
+	if err != nil || token.IamToken != "attr-token" {
+		t.Errorf("expected token from attribute, got %v (%v)", token, err)
+	}
+
+	// DC_TOKEN takes precedence over DC_AUTHKEY
+	creds, diags = configureCredentials(&DoubleCloudProviderModel{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	token, err = creds.(dc.NonExchangeableCredentials).IAMToken(context.Background())
+	if err != nil || token.IamToken != "env-token" {
+		t.Errorf("expected token from DC_TOKEN, got %v (%v)", token, err)
+	}
+
+	// Failed source is named in diagnostics
+	t.Setenv("DC_TOKEN", "")
+	_, diags = configureCredentials(&DoubleCloudProviderModel{})
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), envAuthkey) {
+		t.Errorf("expected error naming %s, got %v", envAuthkey, diags)
+	}
+	_, diags = configureCredentials(&DoubleCloudProviderModel{AuthorizedKeyJSON: types.StringValue("{")})
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "authorized_key_json") {
+		t.Errorf("expected error naming authorized_key_json, got %v", diags)
+	}
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultMaxRetries       = 5
+	defaultRetryMaxInterval = 30 * time.Second
+	retryBaseInterval       = 500 * time.Millisecond
+)
+
+// isReadOnlyMethod reports whether the gRPC method doesn't change anything,
+// e.g. /doublecloud.clickhouse.v1.ClusterService/Get.
+func isReadOnlyMethod(fullMethod string) bool {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "List")
+}
+
+// isRetryable reports whether the call may be repeated after err.
+// Read-only calls are retried on any transient error. Mutating calls are retried
+// only when the request was rejected before it was processed, so they can't be applied twice.
+func isRetryable(fullMethod string, err error) bool {
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.ResourceExhausted:
+		// exhausted quota won't recover by itself, unlike rate limits
+		for _, d := range st.Details() {
+			if _, ok := d.(*errdetails.QuotaFailure); ok {
+				return false
+			}
+		}
+		return true
+	case codes.Aborted:
+		return true
+	case codes.Unavailable:
+		return isReadOnlyMethod(fullMethod)
+	}
+	return false
+}
+
+// retryDelay returns exponential backoff with full jitter for the given attempt.
+func retryDelay(attempt int, maxInterval time.Duration) time.Duration {
+	d := maxInterval
+	if attempt < 32 {
+		if exp := retryBaseInterval << attempt; exp > 0 && exp < maxInterval {
+			d = exp
+		}
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// retryInterceptor repeats failed API calls according to the provider retry settings.
+func (c *Config) retryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for attempt := 0; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= c.MaxRetries || !isRetryable(method, err) {
+				return err
+			}
+			delay := retryDelay(attempt, c.RetryMaxInterval)
+			tflog.Info(ctx, fmt.Sprintf("retrying %s after error: %v", method, err), map[string]interface{}{
+				"attempt": attempt + 1,
+				"delay":   delay.String(),
+			})
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryInterceptor(t *testing.T) {
+	quota, _ := status.New(codes.ResourceExhausted, "quota").WithDetails(&errdetails.QuotaFailure{})
+	for _, tt := range []struct {
+		name     string
+		method   string
+		err      error
+		failures int
+		calls    int
+	}{
+		{"get retried until success", "/doublecloud.clickhouse.v1.ClusterService/Get", status.Error(codes.Unavailable, ""), 2, 3},
+		{"list retried up to the limit", "/doublecloud.kafka.v1.ClusterService/List", status.Error(codes.Aborted, ""), 10, 4},
+		{"create isn't retried when unavailable", "/doublecloud.clickhouse.v1.ClusterService/Create", status.Error(codes.Unavailable, ""), 1, 1},
+		{"create retried when rate limited", "/doublecloud.clickhouse.v1.ClusterService/Create", status.Error(codes.ResourceExhausted, ""), 1, 2},
+		{"exhausted quota isn't retried", "/doublecloud.clickhouse.v1.ClusterService/Create", quota.Err(), 1, 1},
+		{"invalid argument isn't retried", "/doublecloud.clickhouse.v1.ClusterService/Get", status.Error(codes.InvalidArgument, ""), 1, 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{MaxRetries: 3, RetryMaxInterval: time.Millisecond}
+			calls := 0
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				calls++
+				if calls <= tt.failures {
+					return tt.err
+				}
+				return nil
+			}
+			_ = c.retryInterceptor()(context.Background(), tt.method, nil, nil, nil, invoker)
+			if calls != tt.calls {
+				t.Errorf("expected %d calls, got %d", tt.calls, calls)
+			}
+		})
+	}
+}
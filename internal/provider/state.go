@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// setPartialState saves a resource that exists but isn't fully created yet.
+// Create calls it as soon as the base object exists, before any follow-up steps,
+// so the object stays in state even if those steps fail: Terraform then taints
+// the resource and replaces it instead of losing track of it.
+// Computed values unknown at this point are saved as nulls and refreshed by the next Read.
+func setPartialState(ctx context.Context, state *tfsdk.State, data any) diag.Diagnostics {
+	diags := state.Set(ctx, data)
+	if diags.HasError() {
+		return diags
+	}
+	raw, err := tftypes.Transform(state.Raw, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		diags.AddError("failed to save state", err.Error())
+		return diags
+	}
+	state.Raw = raw
+	return diags
+}
+
+// saveFailedCreate reports a failed creation of a resource that already has an ID,
+// saving it to state so Terraform taints it instead of leaking it.
+func saveFailedCreate(ctx context.Context, resp *resource.CreateResponse, data any, err error) {
+	resp.Diagnostics.Append(setPartialState(ctx, &resp.State, data)...)
+	resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+}
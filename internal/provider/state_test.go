@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSetPartialState(t *testing.T) {
+	ctx := context.Background()
+	state := tfsdk.State{
+		Schema: schema.Schema{
+			Attributes: map[string]schema.Attribute{
+				"id":      schema.StringAttribute{Computed: true},
+				"name":    schema.StringAttribute{Required: true},
+				"version": schema.StringAttribute{Optional: true, Computed: true},
+			},
+		},
+		Raw: tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"id":      tftypes.String,
+			"name":    tftypes.String,
+			"version": tftypes.String,
+		}}, nil),
+	}
+	data := struct {
+		Id      types.String `tfsdk:"id"`
+		Name    types.String `tfsdk:"name"`
+		Version types.String `tfsdk:"version"`
+	}{types.StringValue("chc123"), types.StringValue("test"), types.StringUnknown()}
+
+	if diags := setPartialState(ctx, &state, &data); diags.HasError() {
+		t.Fatalf("failed to set partial state: %v", diags)
+	}
+	if !state.Raw.IsFullyKnown() {
+		t.Fatalf("partial state has unknown values: %v", state.Raw)
+	}
+	var id, version types.String
+	state.GetAttribute(ctx, path.Root("id"), &id)
+	state.GetAttribute(ctx, path.Root("version"), &version)
+	if id.ValueString() != "chc123" || !version.IsNull() {
+		t.Errorf("unexpected partial state: id=%v version=%v", id, version)
+	}
+}
@@ -8,6 +8,9 @@ import (
 )
 
 func TestMain(m *testing.M) {
+	if err := setupFakeAPI(); err != nil {
+		log.Fatalf("failed to start fake API: %v", err)
+	}
 	resource.TestMain(m)
 }
 
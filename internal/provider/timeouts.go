@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doublecloud/go-sdk/operation"
+)
+
+// resourceTimeouts are the defaults used when the timeouts block doesn't set a value.
+type resourceTimeouts struct {
+	Create time.Duration
+	Read   time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// waitOperation waits for op to finish. When waiting is interrupted it reports
+// the operation ID and whether the operation may still complete on the server.
+func waitOperation(ctx context.Context, op *operation.Operation) error {
+	err := op.Wait(ctx)
+	if err == nil || op.Failed() {
+		return err
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("timed out waiting for operation %s. The operation was not cancelled and may still complete on the server, increase the timeouts block if it needs more time: %w", op.Id(), err)
+	case ctx.Err() != nil:
+		return fmt.Errorf("stopped waiting for operation %s. The operation was not cancelled and may still complete on the server: %w", op.Id(), err)
+	default:
+		return fmt.Errorf("failed to get status of operation %s, it may still complete on the server: %w", op.Id(), err)
+	}
+}
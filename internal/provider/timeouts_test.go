@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/doublecloud/go-genproto/doublecloud/clickhouse/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/v1"
+	"github.com/doublecloud/go-sdk/operation"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+)
+
+type fakeClickhouseOperations struct {
+	clickhouse.OperationServiceClient
+	op *doublecloud.Operation
+}
+
+func (f *fakeClickhouseOperations) Get(ctx context.Context, in *clickhouse.GetOperationRequest, opts ...grpc.CallOption) (*doublecloud.Operation, error) {
+	return f.op, nil
+}
+
+func TestWaitOperation(t *testing.T) {
+	running := &doublecloud.Operation{Id: "chotimeout", Status: doublecloud.Operation_STATUS_RUNNING}
+	failed := &doublecloud.Operation{Id: "chofailed", Status: doublecloud.Operation_STATUS_DONE, Error: &status.Status{Code: 3, Message: "bad request"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := waitOperation(ctx, operation.New(&fakeClickhouseOperations{op: running}, running))
+	if err == nil || !strings.Contains(err.Error(), "timed out waiting for operation chotimeout") || !strings.Contains(err.Error(), "may still complete") {
+		t.Errorf("unexpected error on timeout: %v", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	err = waitOperation(ctx, operation.New(&fakeClickhouseOperations{op: running}, running))
+	if err == nil || !strings.Contains(err.Error(), "stopped waiting for operation chotimeout") {
+		t.Errorf("unexpected error on cancel: %v", err)
+	}
+
+	err = waitOperation(context.Background(), operation.New(&fakeClickhouseOperations{op: failed}, failed))
+	if err == nil || strings.Contains(err.Error(), "may still complete") || !strings.Contains(err.Error(), "bad request") {
+		t.Errorf("unexpected error on failed operation: %v", err)
+	}
+}
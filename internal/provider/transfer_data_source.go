@@ -21,8 +21,9 @@ func NewTransferDataSource() datasource.DataSource {
 }
 
 type TransferDataSource struct {
-	sdk *dcsdk.SDK
-	svc *dcgen.TransferServiceClient
+	config *Config
+	sdk    *dcsdk.SDK
+	svc    *dcgen.TransferServiceClient
 }
 
 type TransferDataSourceModel struct {
@@ -43,7 +44,8 @@ func (d *TransferDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		MarkdownDescription: "Transfer data source",
 		Attributes: map[string]schema.Attribute{
 			"project_id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "Project identifier",
 			},
 			"id": schema.StringAttribute{
@@ -77,17 +79,18 @@ func (d *TransferDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	sdk, ok := req.ProviderData.(*dcsdk.SDK)
+	config, ok := req.ProviderData.(*Config)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *dcsdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.sdk = sdk
+	d.config = config
+	d.sdk = config.sdk
 	d.svc = d.sdk.Transfer().Transfer()
 }
 
@@ -101,29 +104,30 @@ func (d *TransferDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
+	projectId, diags := d.config.projectId(data.ProjectID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ProjectID = projectId
+
 	if data.Id == types.StringNull() && data.Name == types.StringNull() {
 		resp.Diagnostics.AddError("missing attribute", "specify one of: id or name")
 		return
 	}
 
 	if data.Id == types.StringNull() {
-		it := d.svc.TransferIterator(ctx, &transfer.ListTransfersRequest{ProjectId: data.ProjectID.ValueString()})
-		for it.Next() {
-			c := it.Value()
-			if c.Name == data.Name.ValueString() {
-				data.Id = types.StringValue(c.Id)
-				break
-			}
-		}
-		if data.Id == types.StringNull() {
-			resp.Diagnostics.AddError("transfer not found", fmt.Sprintf("transfer `%v` haven't found", data.Name.ValueString()))
+		id, diags := lookupByName(ctx, "transfer", data.ProjectID.ValueString(), data.Name.ValueString(), d.config.transfers)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+		data.Id = types.StringValue(id)
 	}
 
 	response, err := d.svc.Get(ctx, &transfer.GetTransferRequest{TransferId: data.Id.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 		return
 	}
 
@@ -227,37 +227,73 @@ func chTargetEndpointSettings(m *endpointClickhouseTargetSettings) (*transfer.En
 	return settings, nil
 }
 
+func (m *endpointClickhouseSourceSettings) parse(ctx context.Context, e *endpoint.ClickhouseSource) diag.Diagnostics {
+	var diag diag.Diagnostics
+
+	if m.Connection == nil {
+		m.Connection = &endpointClickhouseConnectionOptions{}
+	}
+	diag.Append(parseTransferEndpointClickhouseConnection(ctx, e.Connection, m.Connection)...)
+	m.IncludeTables = convertSliceToTFStrings(e.IncludeTables)
+	m.ExcludeTables = convertSliceToTFStrings(e.ExcludeTables)
+
+	return diag
+}
+
+func (m *endpointClickhouseTargetSettings) parse(ctx context.Context, e *endpoint.ClickhouseTarget) diag.Diagnostics {
+	var diag diag.Diagnostics
+
+	m.ClickhouseClusterName = types.StringValue(e.ClickhouseClusterName)
+	m.ClickhouseCleanupPolicy = types.StringValue(endpoint.CleanupPolicy_name[int32(e.CleanupPolicy.Number())])
+
+	altNames := make([]altName, len(e.AltNames))
+	for i, v := range e.AltNames {
+		if i < len(m.AltNames) {
+			altNames[i] = m.AltNames[i]
+		}
+		altNames[i].FromName = parseOptionalString(altNames[i].FromName, v.FromName)
+		altNames[i].ToName = parseOptionalString(altNames[i].ToName, v.ToName)
+	}
+	m.AltNames = altNames
+
+	if m.Connection == nil {
+		m.Connection = &endpointClickhouseConnectionOptions{}
+	}
+	diag.Append(parseTransferEndpointClickhouseConnection(ctx, e.Connection, m.Connection)...)
+
+	return diag
+}
+
 func parseTransferEndpointClickhouseConnection(ctx context.Context, e *endpoint.ClickhouseConnection, c *endpointClickhouseConnectionOptions) diag.Diagnostics {
 	var diag diag.Diagnostics
 
 	opts := e.GetConnectionOptions()
-	c.User = types.StringValue(opts.User)
-	c.Database = types.StringValue(opts.Database)
+	c.User = parseOptionalString(c.User, opts.GetUser())
+	c.Database = parseOptionalString(c.Database, opts.GetDatabase())
+	if c.Address == nil {
+		c.Address = &endpointClickhouseConnectionAddress{}
+	}
 	if addr := opts.GetMdbClusterId(); addr != "" {
 		c.Address.ClusterId = types.StringValue(addr)
 	}
 	if addr := opts.GetOnPremise(); addr != nil {
+		if c.Address.OnPremise == nil {
+			c.Address.OnPremise = &onPremiseClickhouse{}
+		}
 		on_prem := c.Address.OnPremise
 		on_prem.HttpPort = types.Int64Value(addr.HttpPort)
 		on_prem.NativePort = types.Int64Value(addr.NativePort)
-		if addr.TlsMode != nil {
-			if disabled := addr.TlsMode.GetDisabled(); disabled != nil {
-				on_prem.TLSMode = nil
-			}
-			if config := addr.TlsMode.GetEnabled(); config != nil {
-				on_prem.TLSMode = &endpointTLSMode{CACertificate: types.StringValue(config.CaCertificate)}
-			}
-		}
+		on_prem.TLSMode = parseTLSMode(addr.TlsMode, on_prem.TLSMode)
 
-		if addr.Shards != nil {
-			on_prem.Shards = make([]endpointClickhouseShards, len(addr.Shards))
-			for i := 0; i < len(addr.Shards); i++ {
-				on_prem.Shards[i] = endpointClickhouseShards{
-					Name:  types.StringValue(addr.Shards[i].Name),
-					Hosts: convertSliceToTFStrings(addr.Shards[i].Hosts),
-				}
+		shards := make([]endpointClickhouseShards, len(addr.Shards))
+		for i, v := range addr.Shards {
+			if i < len(on_prem.Shards) {
+				shards[i] = on_prem.Shards[i]
 			}
+			shards[i].Name = parseOptionalString(shards[i].Name, v.Name)
+			shards[i].Hosts = convertSliceToTFStrings(v.Hosts)
 		}
+		on_prem.Shards = shards
 	}
 
 	return diag
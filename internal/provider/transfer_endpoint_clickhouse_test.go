@@ -178,3 +178,96 @@ resource "doublecloud_transfer_endpoint" %[2]q {
 }
 `, testEChSourceName, testEChTargetName, m.ProjectID.ValueString())
 }
+
+func clickhouseOnPremiseConnection() *endpointClickhouseConnectionOptions {
+	return &endpointClickhouseConnectionOptions{
+		Address: &endpointClickhouseConnectionAddress{OnPremise: &onPremiseClickhouse{
+			HttpPort:   types.Int64Value(8443),
+			NativePort: types.Int64Value(8443),
+			Shards: []endpointClickhouseShards{
+				{Name: types.StringValue("first"), Hosts: []types.String{types.StringValue("127.0.0.1"), types.StringValue("127.0.0.2")}},
+			},
+		}},
+	}
+}
+
+var clickhouseRoundTripCases = []endpointRoundTripCase{
+	{
+		name: "clickhouse_source",
+		base: func() *endpointSettings {
+			return &endpointSettings{ClickhouseSource: &endpointClickhouseSourceSettings{
+				Connection: &endpointClickhouseConnectionOptions{
+					Address: &endpointClickhouseConnectionAddress{ClusterId: types.StringValue("chcexample")},
+				},
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.ClickhouseSource.Connection.Database = types.StringValue("default") },
+			func(s *endpointSettings) { s.ClickhouseSource.Connection.User = types.StringValue("admin") },
+			func(s *endpointSettings) { s.ClickhouseSource.Connection.Password = types.StringValue("foobar123") },
+			func(s *endpointSettings) {
+				s.ClickhouseSource.IncludeTables = []types.String{types.StringValue("foo"), types.StringValue("bar")}
+			},
+			func(s *endpointSettings) {
+				s.ClickhouseSource.ExcludeTables = []types.String{types.StringValue("wolf")}
+			},
+		},
+	},
+	{
+		name: "clickhouse_source_on_premise",
+		base: func() *endpointSettings {
+			return &endpointSettings{ClickhouseSource: &endpointClickhouseSourceSettings{
+				Connection: clickhouseOnPremiseConnection(),
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) {
+				s.ClickhouseSource.Connection.Address.OnPremise.NativePort = types.Int64Value(9440)
+			},
+			func(s *endpointSettings) {
+				s.ClickhouseSource.Connection.Address.OnPremise.TLSMode = &endpointTLSMode{CACertificate: types.StringValue("<pem>")}
+			},
+			func(s *endpointSettings) {
+				s.ClickhouseSource.Connection.Address.OnPremise.Shards[0].Name = types.StringNull()
+			},
+		},
+	},
+	{
+		name: "clickhouse_target",
+		base: func() *endpointSettings {
+			return &endpointSettings{ClickhouseTarget: &endpointClickhouseTargetSettings{
+				Connection:              clickhouseOnPremiseConnection(),
+				ClickhouseClusterName:   types.StringValue(""),
+				ClickhouseCleanupPolicy: types.StringValue("DISABLED"),
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.ClickhouseTarget.ClickhouseClusterName = types.StringValue("production") },
+			func(s *endpointSettings) { s.ClickhouseTarget.ClickhouseCleanupPolicy = types.StringValue("DROP") },
+			func(s *endpointSettings) {
+				s.ClickhouseTarget.AltNames = []altName{
+					{FromName: types.StringValue("foo"), ToName: types.StringValue("bar")},
+					{FromName: types.StringValue("bunny"), ToName: types.StringValue("wolf")},
+				}
+			},
+			func(s *endpointSettings) { s.ClickhouseTarget.Connection.Database = types.StringValue("default") },
+			func(s *endpointSettings) { s.ClickhouseTarget.Connection.User = types.StringValue("admin") },
+			func(s *endpointSettings) { s.ClickhouseTarget.Connection.Password = types.StringValue("foobar123") },
+			func(s *endpointSettings) {
+				s.ClickhouseTarget.Connection.Address.OnPremise.Shards = append(s.ClickhouseTarget.Connection.Address.OnPremise.Shards,
+					endpointClickhouseShards{Name: types.StringValue("second"), Hosts: []types.String{types.StringValue("1.1.1.1")}})
+			},
+			func(s *endpointSettings) {
+				s.ClickhouseTarget.Connection.Address.OnPremise.TLSMode = &endpointTLSMode{}
+			},
+		},
+	},
+}
+
+func TestTransferEndpointClickhouseRoundTrip(t *testing.T) {
+	testEndpointRoundTripCases(t, clickhouseRoundTripCases)
+}
+
+func FuzzTransferEndpointClickhouseRoundTrip(f *testing.F) {
+	fuzzEndpointRoundTrip(f, clickhouseRoundTripCases)
+}
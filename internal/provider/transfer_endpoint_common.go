@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type endpointTLSMode struct {
@@ -72,6 +73,52 @@ func convertTLSMode(m *endpointTLSMode) *endpoint.TLSMode {
 	return &endpoint.TLSMode{TlsMode: &endpoint.TLSMode_Enabled{Enabled: &endpoint.TLSConfig{CaCertificate: m.CACertificate.ValueString()}}}
 }
 
+// parseTLSMode keeps the block missing for disabled TLS, as convertTLSMode does.
+func parseTLSMode(e *endpoint.TLSMode, m *endpointTLSMode) *endpointTLSMode {
+	config := e.GetEnabled()
+	if config == nil {
+		return nil
+	}
+	if m == nil {
+		m = &endpointTLSMode{}
+	}
+	m.CACertificate = parseOptionalString(m.CACertificate, config.CaCertificate)
+	return m
+}
+
+// The API returns zero values for unset fields, optional attributes stay null for them.
+
+func parseOptionalString(m types.String, v string) types.String {
+	if m.IsNull() && v == "" {
+		return m
+	}
+	return types.StringValue(v)
+}
+
+func parseOptionalInt64(m types.Int64, v int64) types.Int64 {
+	if m.IsNull() && v == 0 {
+		return m
+	}
+	return types.Int64Value(v)
+}
+
+func parseOptionalBool(m types.Bool, v bool) types.Bool {
+	if m.IsNull() && !v {
+		return m
+	}
+	return types.BoolValue(v)
+}
+
+func parseOptionalEnum(m types.String, v interface {
+	Number() protoreflect.EnumNumber
+	String() string
+}) types.String {
+	if m.IsNull() && v.Number() == 0 {
+		return m
+	}
+	return types.StringValue(v.String())
+}
+
 func transferEndpointGenericParserSchema() schema.SingleNestedBlock {
 	return schema.SingleNestedBlock{
 		Attributes: map[string]schema.Attribute{
@@ -214,7 +214,7 @@ func convertKafkaAuth(m *endpointKafkaAuth) (*endpoint.KafkaAuth, diag.Diagnosti
 	options := &endpoint.KafkaAuth{}
 
 	if m.NoAuth != nil {
-		options.Security = &endpoint.KafkaAuth_NoAuth{}
+		options.Security = &endpoint.KafkaAuth_NoAuth{NoAuth: &endpoint.NoAuth{}}
 	}
 	if m.SASL != nil && m.NoAuth == nil {
 		sasl := &endpoint.KafkaSaslSecurity{
@@ -286,10 +286,10 @@ func convertSerializer(m *endpointSerializer) (*endpoint.Serializer, diag.Diagno
 
 	s := &endpoint.Serializer{}
 	if m.Auto != nil && s.Serializer == nil {
-		s.Serializer = &endpoint.Serializer_SerializerAuto{}
+		s.Serializer = &endpoint.Serializer_SerializerAuto{SerializerAuto: &endpoint.SerializerAuto{}}
 	}
 	if m.JSON != nil && s.Serializer == nil {
-		s.Serializer = &endpoint.Serializer_SerializerJson{}
+		s.Serializer = &endpoint.Serializer_SerializerJson{SerializerJson: &endpoint.SerializerJSON{}}
 	}
 	if m.Debezium != nil && s.Serializer == nil {
 		parameters := make([]*endpoint.DebeziumSerializerParameter, 0)
@@ -330,59 +330,44 @@ func kafkaTargetEndpointSettings(m *endpointKafkaTargetSettings) (*transfer.Endp
 }
 
 func parseTransferEndpointKafkaAuth(e *endpoint.KafkaAuth, m *endpointKafkaAuth) {
-	if e == nil {
-		m = nil
-	}
-
 	if no_auth := e.GetNoAuth(); no_auth != nil {
-		m = &endpointKafkaAuth{NoAuth: &endpointKafkAuthNoAuth{}}
+		m.NoAuth = &endpointKafkAuthNoAuth{}
 	}
 
 	if sasl := e.GetSasl(); sasl != nil {
-		if m == nil {
-			m = &endpointKafkaAuth{SASL: &endpointKafkaAuthSASL{}}
-		}
-		if !m.SASL.User.IsNull() {
-			m.SASL.User = types.StringValue(sasl.User)
-		}
-		if !m.SASL.Mechanism.IsNull() {
-			m.SASL.Mechanism = types.StringValue(sasl.Mechanism.String())
+		if m.SASL == nil {
+			m.SASL = &endpointKafkaAuthSASL{}
 		}
+		m.SASL.User = parseOptionalString(m.SASL.User, sasl.User)
+		m.SASL.Mechanism = parseOptionalEnum(m.SASL.Mechanism, sasl.Mechanism)
 	}
 }
 
 func parseTransferEndpointKafkaConnection(e *endpoint.KafkaConnectionOptions, m *endpointKafkaConnectionOptions) {
-	if e == nil {
-		m = nil
-	}
-
 	if cluster_id := e.GetClusterId(); cluster_id != "" {
 		m.ClusterId = types.StringValue(cluster_id)
 	}
 	if on_premise := e.GetOnPremise(); on_premise != nil {
-		if m == nil {
-			m = &endpointKafkaConnectionOptions{}
-		}
-		if m.OnPremise.BrokerUrls != nil {
-			m.OnPremise.BrokerUrls = convertSliceToTFStrings(on_premise.BrokerUrls)
-		}
-		if m.OnPremise.TLSMode != nil {
-			if disabled := on_premise.TlsMode.GetDisabled(); disabled != nil {
-				m.OnPremise.TLSMode = nil
-			}
-			if config := on_premise.TlsMode.GetEnabled(); config != nil {
-				m.OnPremise.TLSMode = &endpointTLSMode{CACertificate: types.StringValue(config.CaCertificate)}
-			}
+		if m.OnPremise == nil {
+			m.OnPremise = &endpointOnPremiseKafka{}
 		}
+		m.OnPremise.BrokerUrls = convertSliceToTFStrings(on_premise.BrokerUrls)
+		m.OnPremise.TLSMode = parseTLSMode(on_premise.TlsMode, m.OnPremise.TLSMode)
 	}
 }
 
 func parseTransferEndpointKafkaSource(ctx context.Context, e *endpoint.KafkaSource, c *endpointKafkaSourceSettings) diag.Diagnostics {
 	var diag diag.Diagnostics
 
+	if c.Auth == nil {
+		c.Auth = &endpointKafkaAuth{}
+	}
 	parseTransferEndpointKafkaAuth(e.Auth, c.Auth)
+	if c.Connection == nil {
+		c.Connection = &endpointKafkaConnectionOptions{}
+	}
 	parseTransferEndpointKafkaConnection(e.Connection, c.Connection)
-	c.TopicName = types.StringValue(e.TopicName)
+	c.TopicName = parseOptionalString(c.TopicName, e.TopicName)
 
 	return diag
 }
@@ -390,12 +375,21 @@ func parseTransferEndpointKafkaSource(ctx context.Context, e *endpoint.KafkaSour
 func parseTransferEndpointKafkaTarget(ctx context.Context, e *endpoint.KafkaTarget, c *endpointKafkaTargetSettings) diag.Diagnostics {
 	var diag diag.Diagnostics
 
+	if c.Auth == nil {
+		c.Auth = &endpointKafkaAuth{}
+	}
 	parseTransferEndpointKafkaAuth(e.Auth, c.Auth)
+	if c.Connection == nil {
+		c.Connection = &endpointKafkaConnectionOptions{}
+	}
 	parseTransferEndpointKafkaConnection(e.Connection, c.Connection)
 
 	if e.Serializer == nil {
 		c.Serializer = nil
 	} else {
+		if c.Serializer == nil {
+			c.Serializer = &endpointSerializer{}
+		}
 		if auto := e.Serializer.GetSerializerAuto(); auto != nil {
 			c.Serializer.Auto = &endpointSerializerAuto{}
 		}
@@ -403,15 +397,23 @@ func parseTransferEndpointKafkaTarget(ctx context.Context, e *endpoint.KafkaTarg
 			c.Serializer.JSON = &endpointSerializerJSON{}
 		}
 		if debezium := e.Serializer.GetSerializerDebezium(); debezium != nil {
-			c.Serializer.Debezium = &endpointSerializerDebezium{}
-			if len(debezium.SerializerParameters) != 0 {
-				p := make([]endpointSerializerDebeziumParameter, len(debezium.SerializerParameters))
-				for i := 0; i < len(debezium.SerializerParameters); i++ {
-					p[i] = endpointSerializerDebeziumParameter{
-						Key:   types.StringValue(debezium.SerializerParameters[i].Key),
-						Value: types.StringValue(debezium.SerializerParameters[i].Value),
-					}
+			if c.Serializer.Debezium == nil {
+				c.Serializer.Debezium = &endpointSerializerDebezium{}
+			}
+			var prior []endpointSerializerDebeziumParameter
+			if c.Serializer.Debezium.Parameter != nil {
+				prior = *c.Serializer.Debezium.Parameter
+			}
+			p := make([]endpointSerializerDebeziumParameter, len(debezium.SerializerParameters))
+			for i, v := range debezium.SerializerParameters {
+				if i < len(prior) {
+					p[i] = prior[i]
 				}
+				p[i].Key = parseOptionalString(p[i].Key, v.Key)
+				p[i].Value = parseOptionalString(p[i].Value, v.Value)
+			}
+			if len(p) != 0 || c.Serializer.Debezium.Parameter != nil {
+				c.Serializer.Debezium.Parameter = &p
 			}
 		}
 	}
@@ -419,6 +421,9 @@ func parseTransferEndpointKafkaTarget(ctx context.Context, e *endpoint.KafkaTarg
 	if e.TopicSettings == nil {
 		c.TopicSettings = nil
 	} else {
+		if c.TopicSettings == nil {
+			c.TopicSettings = &endpointKafkaTopicSettings{}
+		}
 		if prefix := e.TopicSettings.GetTopicPrefix(); prefix != "" {
 			c.TopicSettings.TopicPrefix = types.StringValue(prefix)
 		}
@@ -426,12 +431,8 @@ func parseTransferEndpointKafkaTarget(ctx context.Context, e *endpoint.KafkaTarg
 			if c.TopicSettings.Topic == nil {
 				c.TopicSettings.Topic = &endpointKafkaTargetTopic{}
 			}
-			if topic.TopicName != "" {
-				c.TopicSettings.Topic.TopicName = types.StringValue(topic.TopicName)
-			}
-			if topic.SaveTxOrder {
-				c.TopicSettings.Topic.SaveTxOrder = types.BoolValue(topic.SaveTxOrder)
-			}
+			c.TopicSettings.Topic.TopicName = parseOptionalString(c.TopicSettings.Topic.TopicName, topic.TopicName)
+			c.TopicSettings.Topic.SaveTxOrder = parseOptionalBool(c.TopicSettings.Topic.SaveTxOrder, topic.SaveTxOrder)
 		}
 	}
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -145,3 +146,96 @@ resource "doublecloud_transfer_endpoint" %[2]q {
 }
 `, testEKfSourceName, testEKfTargetName, testProjectId)
 }
+
+func kafkaOnPremiseConnection() *endpointKafkaConnectionOptions {
+	return &endpointKafkaConnectionOptions{OnPremise: &endpointOnPremiseKafka{
+		BrokerUrls: []types.String{types.StringValue("broker-0.company.tech:9091"), types.StringValue("broker-1.company.tech:9091")},
+	}}
+}
+
+func kafkaSASLAuth() *endpointKafkaAuth {
+	return &endpointKafkaAuth{SASL: &endpointKafkaAuthSASL{User: types.StringValue("sink-user")}}
+}
+
+var kafkaRoundTripCases = []endpointRoundTripCase{
+	{
+		name: "kafka_source",
+		base: func() *endpointSettings {
+			return &endpointSettings{KafkaSource: &endpointKafkaSourceSettings{
+				Connection: &endpointKafkaConnectionOptions{ClusterId: types.StringValue("kfkexample")},
+				Auth:       &endpointKafkaAuth{NoAuth: &endpointKafkAuthNoAuth{}},
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.KafkaSource.TopicName = types.StringValue("orders") },
+		},
+	},
+	{
+		name: "kafka_source_on_premise",
+		base: func() *endpointSettings {
+			return &endpointSettings{KafkaSource: &endpointKafkaSourceSettings{
+				Connection: kafkaOnPremiseConnection(),
+				Auth:       kafkaSASLAuth(),
+				TopicName:  types.StringValue("orders"),
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.KafkaSource.Auth.SASL.Password = types.StringValue("foobar123") },
+			func(s *endpointSettings) {
+				s.KafkaSource.Auth.SASL.Mechanism = types.StringValue("KAFKA_MECHANISM_SHA512")
+			},
+			func(s *endpointSettings) {
+				s.KafkaSource.Connection.OnPremise.TLSMode = &endpointTLSMode{CACertificate: types.StringValue("<pem>")}
+			},
+		},
+	},
+	{
+		name: "kafka_target_topic_prefix",
+		base: func() *endpointSettings {
+			return &endpointSettings{KafkaTarget: &endpointKafkaTargetSettings{
+				Connection:    &endpointKafkaConnectionOptions{ClusterId: types.StringValue("kfkexample")},
+				Auth:          &endpointKafkaAuth{NoAuth: &endpointKafkAuthNoAuth{}},
+				TopicSettings: &endpointKafkaTopicSettings{TopicPrefix: types.StringValue("cdc")},
+				Serializer:    &endpointSerializer{Auto: &endpointSerializerAuto{}},
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) {
+				s.KafkaTarget.Serializer = &endpointSerializer{JSON: &endpointSerializerJSON{}}
+			},
+		},
+	},
+	{
+		name: "kafka_target_topic",
+		base: func() *endpointSettings {
+			return &endpointSettings{KafkaTarget: &endpointKafkaTargetSettings{
+				Connection:    kafkaOnPremiseConnection(),
+				Auth:          kafkaSASLAuth(),
+				TopicSettings: &endpointKafkaTopicSettings{Topic: &endpointKafkaTargetTopic{}},
+				Serializer:    &endpointSerializer{Debezium: &endpointSerializerDebezium{}},
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.KafkaTarget.TopicSettings.Topic.TopicName = types.StringValue("orders") },
+			func(s *endpointSettings) { s.KafkaTarget.TopicSettings.Topic.SaveTxOrder = types.BoolValue(true) },
+			func(s *endpointSettings) { s.KafkaTarget.TopicSettings.Topic.SaveTxOrder = types.BoolValue(false) },
+			func(s *endpointSettings) {
+				s.KafkaTarget.Serializer.Debezium.Parameter = &[]endpointSerializerDebeziumParameter{
+					{Key: types.StringValue("key.converter.schemas.enable"), Value: types.StringValue("false")},
+					{Key: types.StringValue("dt.unknown.types.policy"), Value: types.StringValue("skip")},
+				}
+			},
+			func(s *endpointSettings) {
+				s.KafkaTarget.Auth.SASL.Mechanism = types.StringValue("KAFKA_MECHANISM_SHA256")
+			},
+		},
+	},
+}
+
+func TestTransferEndpointKafkaRoundTrip(t *testing.T) {
+	testEndpointRoundTripCases(t, kafkaRoundTripCases)
+}
+
+func FuzzTransferEndpointKafkaRoundTrip(f *testing.F) {
+	fuzzEndpointRoundTrip(f, kafkaRoundTripCases)
+}
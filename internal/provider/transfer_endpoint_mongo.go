@@ -231,31 +231,18 @@ func (m *endpointMongoTargetSettings) convert() (*transfer.EndpointSettings_Mong
 
 func (m *endpointMongoConnection) parse(e *endpoint.MongoConnection) diag.Diagnostics {
 	var diags diag.Diagnostics
-	if e == nil {
-		m = nil
-	}
 
 	opts := e.GetConnectionOptions()
-	m.User = types.StringValue(opts.User)
-	m.AuthSource = types.StringValue(opts.AuthSource)
+	m.User = parseOptionalString(m.User, opts.GetUser())
+	m.AuthSource = parseOptionalString(m.AuthSource, opts.GetAuthSource())
 	if on_premise := opts.GetOnPremise(); on_premise != nil {
-		if m.OnPremise.Hosts != nil {
-			m.OnPremise.Hosts = convertSliceToTFStrings(on_premise.Hosts)
-		}
-		if !m.OnPremise.Port.IsNull() {
-			m.OnPremise.Port = types.Int64Value(on_premise.Port)
-		}
-		if !m.OnPremise.ReplicaSet.IsNull() {
-			m.OnPremise.ReplicaSet = types.StringValue(on_premise.ReplicaSet)
-		}
-		if m.OnPremise.TLSMode != nil {
-			if disabled := on_premise.TlsMode.GetDisabled(); disabled != nil {
-				m.OnPremise.TLSMode = nil
-			}
-			if config := on_premise.TlsMode.GetEnabled(); config != nil {
-				m.OnPremise.TLSMode = &endpointTLSMode{CACertificate: types.StringValue(config.CaCertificate)}
-			}
+		if m.OnPremise == nil {
+			m.OnPremise = &endpointMongoConnectionOnPremise{}
 		}
+		m.OnPremise.Hosts = convertSliceToTFStrings(on_premise.Hosts)
+		m.OnPremise.Port = parseOptionalInt64(m.OnPremise.Port, on_premise.Port)
+		m.OnPremise.ReplicaSet = parseOptionalString(m.OnPremise.ReplicaSet, on_premise.ReplicaSet)
+		m.OnPremise.TLSMode = parseTLSMode(on_premise.TlsMode, m.OnPremise.TLSMode)
 	} else {
 		diags.AddError("unsupported mongo address type", "update your provider")
 	}
@@ -263,28 +250,28 @@ func (m *endpointMongoConnection) parse(e *endpoint.MongoConnection) diag.Diagno
 	return diags
 }
 
+func parseMongoCollections(e []*endpoint.MongoCollection, m []endpointMongoCollection) []endpointMongoCollection {
+	ret := make([]endpointMongoCollection, len(e))
+	for i, v := range e {
+		if i < len(m) {
+			ret[i] = m[i]
+		}
+		ret[i].DatabaseName = parseOptionalString(ret[i].DatabaseName, v.DatabaseName)
+		ret[i].CollectionName = parseOptionalString(ret[i].CollectionName, v.CollectionName)
+	}
+	return ret
+}
+
 func (m *endpointMongoSourceSettings) parse(e *endpoint.MongoSource) diag.Diagnostics {
 	var diag diag.Diagnostics
 
-	m.Connection.parse(e.Connection)
-	if !m.SecondaryPreferredMode.IsNull() {
-		m.SecondaryPreferredMode = types.BoolValue(e.SecondaryPreferredMode)
-	}
-
-	m.Collections = make([]endpointMongoCollection, len(e.Collections))
-	for i := 0; i < len(e.Collections); i++ {
-		m.Collections[i] = endpointMongoCollection{
-			DatabaseName:   types.StringValue(e.Collections[i].DatabaseName),
-			CollectionName: types.StringValue(e.Collections[i].CollectionName),
-		}
-	}
-	m.ExcludedCollections = make([]endpointMongoCollection, len(e.ExcludedCollections))
-	for i := 0; i < len(e.ExcludedCollections); i++ {
-		m.ExcludedCollections[i] = endpointMongoCollection{
-			DatabaseName:   types.StringValue(e.ExcludedCollections[i].DatabaseName),
-			CollectionName: types.StringValue(e.ExcludedCollections[i].CollectionName),
-		}
+	if m.Connection == nil {
+		m.Connection = &endpointMongoConnection{}
 	}
+	diag.Append(m.Connection.parse(e.Connection)...)
+	m.SecondaryPreferredMode = parseOptionalBool(m.SecondaryPreferredMode, e.SecondaryPreferredMode)
+	m.Collections = parseMongoCollections(e.Collections, m.Collections)
+	m.ExcludedCollections = parseMongoCollections(e.ExcludedCollections, m.ExcludedCollections)
 
 	return diag
 }
@@ -292,7 +279,10 @@ func (m *endpointMongoSourceSettings) parse(e *endpoint.MongoSource) diag.Diagno
 func (m *endpointMongoTargetSettings) parse(e *endpoint.MongoTarget) diag.Diagnostics {
 	var diag diag.Diagnostics
 
-	m.Connection.parse(e.Connection)
+	if m.Connection == nil {
+		m.Connection = &endpointMongoConnection{}
+	}
+	diag.Append(m.Connection.parse(e.Connection)...)
 	m.Database = types.StringValue(e.Database)
 	m.CleanupPolicy = types.StringValue(e.CleanupPolicy.String())
 	return diag
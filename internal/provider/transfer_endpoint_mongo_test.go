@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -159,3 +160,68 @@ resource "doublecloud_transfer_endpoint" %[2]q {
 }
 `, testEMgSourceName, testEMgTargetName, testProjectId)
 }
+
+func mongoOnPremiseConnection() *endpointMongoConnection {
+	return &endpointMongoConnection{OnPremise: &endpointMongoConnectionOnPremise{
+		Hosts: []types.String{types.StringValue("leader-0.company.tech")},
+	}}
+}
+
+var mongoRoundTripCases = []endpointRoundTripCase{
+	{
+		name: "mongo_source",
+		base: func() *endpointSettings {
+			return &endpointSettings{MongoSource: &endpointMongoSourceSettings{
+				Connection: mongoOnPremiseConnection(),
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.MongoSource.Connection.OnPremise.Port = types.Int64Value(27015) },
+			func(s *endpointSettings) { s.MongoSource.Connection.OnPremise.ReplicaSet = types.StringValue("rs01") },
+			func(s *endpointSettings) {
+				s.MongoSource.Connection.OnPremise.TLSMode = &endpointTLSMode{CACertificate: types.StringValue("<pem>")}
+			},
+			func(s *endpointSettings) { s.MongoSource.Connection.User = types.StringValue("dc-transfer") },
+			func(s *endpointSettings) { s.MongoSource.Connection.Password = types.StringValue("foobar123") },
+			func(s *endpointSettings) { s.MongoSource.Connection.AuthSource = types.StringValue("admin") },
+			func(s *endpointSettings) { s.MongoSource.SecondaryPreferredMode = types.BoolValue(true) },
+			func(s *endpointSettings) { s.MongoSource.SecondaryPreferredMode = types.BoolValue(false) },
+			func(s *endpointSettings) {
+				s.MongoSource.Collections = []endpointMongoCollection{
+					{DatabaseName: types.StringValue("production"), CollectionName: types.StringValue("users")},
+					{DatabaseName: types.StringValue("analytics")},
+				}
+			},
+			func(s *endpointSettings) {
+				s.MongoSource.ExcludedCollections = []endpointMongoCollection{
+					{DatabaseName: types.StringValue("production"), CollectionName: types.StringValue("sessions")},
+				}
+			},
+		},
+	},
+	{
+		name: "mongo_target",
+		base: func() *endpointSettings {
+			return &endpointSettings{MongoTarget: &endpointMongoTargetSettings{
+				Connection:    mongoOnPremiseConnection(),
+				Database:      types.StringUnknown(),
+				CleanupPolicy: types.StringUnknown(),
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.MongoTarget.Connection.OnPremise.Port = types.Int64Value(27017) },
+			func(s *endpointSettings) { s.MongoTarget.Connection.OnPremise.TLSMode = &endpointTLSMode{} },
+			func(s *endpointSettings) { s.MongoTarget.Connection.User = types.StringValue("dc-transfer") },
+			func(s *endpointSettings) { s.MongoTarget.Database = types.StringValue("production") },
+			func(s *endpointSettings) { s.MongoTarget.CleanupPolicy = types.StringValue("DROP") },
+		},
+	},
+}
+
+func TestTransferEndpointMongoRoundTrip(t *testing.T) {
+	testEndpointRoundTripCases(t, mongoRoundTripCases)
+}
+
+func FuzzTransferEndpointMongoRoundTrip(f *testing.F) {
+	fuzzEndpointRoundTrip(f, mongoRoundTripCases)
+}
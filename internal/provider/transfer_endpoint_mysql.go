@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
 )
 
 type endpointMysqlSourceSettings struct {
@@ -327,55 +328,58 @@ func (m *endpointMysqlTargetSettings) convert() (*transfer.EndpointSettings_Mysq
 }
 
 func (m *endpointMysqlConnection) parse(e *endpoint.MysqlConnection) {
-	if e == nil {
-		m = nil
-	}
-
 	if on_premise := e.GetOnPremise(); on_premise != nil {
-		if m == nil {
-			m = &endpointMysqlConnection{}
-		}
-		if m.OnPremise.Hosts != nil {
-			m.OnPremise.Hosts = convertSliceToTFStrings(on_premise.Hosts)
-		}
-		if !m.OnPremise.Port.IsNull() {
-			m.OnPremise.Port = types.Int64Value(on_premise.Port)
-		}
-		if m.OnPremise.TLSMode != nil {
-			if disabled := on_premise.TlsMode.GetDisabled(); disabled != nil {
-				m.OnPremise.TLSMode = nil
-			}
-			if config := on_premise.TlsMode.GetEnabled(); config != nil {
-				m.OnPremise.TLSMode = &endpointTLSMode{CACertificate: types.StringValue(config.CaCertificate)}
-			}
+		if m.OnPremise == nil {
+			m.OnPremise = &endpointMysqlOnPremise{}
 		}
+		m.OnPremise.Hosts = convertSliceToTFStrings(on_premise.Hosts)
+		m.OnPremise.Port = parseOptionalInt64(m.OnPremise.Port, on_premise.Port)
+		m.OnPremise.TLSMode = parseTLSMode(on_premise.TlsMode, m.OnPremise.TLSMode)
 	}
 }
 
+func (m *endpointMysqlObjectTransferSettings) parse(e *endpoint.MysqlObjectTransferSettings) {
+	m.View = parseOptionalEnum(m.View, e.GetView())
+	m.Routine = parseOptionalEnum(m.Routine, e.GetRoutine())
+	m.Trigger = parseOptionalEnum(m.Trigger, e.GetTrigger())
+	m.Tables = parseOptionalEnum(m.Tables, e.GetTables())
+}
+
 func (m *endpointMysqlSourceSettings) parse(e *endpoint.MysqlSource) diag.Diagnostics {
 	var diag diag.Diagnostics
 
+	if m.Connection == nil {
+		m.Connection = &endpointMysqlConnection{}
+	}
 	m.Connection.parse(e.Connection)
-	m.Database = types.StringValue(e.Database)
+	m.Database = parseOptionalString(m.Database, e.Database)
 	m.ServiceDatabase = types.StringValue(e.ServiceDatabase)
-	m.User = types.StringValue(e.User)
+	m.User = parseOptionalString(m.User, e.User)
 	m.IncludeTablesRegex = convertSliceToTFStrings(e.IncludeTablesRegex)
 	m.ExcludeTablesRegex = convertSliceToTFStrings(e.ExcludeTablesRegex)
 	m.Timezone = types.StringValue(e.Timezone)
 
-	// TODO: Fix bug with default empty block
-	// parse ObjectTransferSettings
+	// default settings are returned as an empty block, keep it missing unless configured
+	if s := e.ObjectTransferSettings; m.ObjectTransferSettings != nil || proto.Size(s) != 0 {
+		if m.ObjectTransferSettings == nil {
+			m.ObjectTransferSettings = &endpointMysqlObjectTransferSettings{}
+		}
+		m.ObjectTransferSettings.parse(s)
+	}
 	return diag
 }
 
 func (m *endpointMysqlTargetSettings) parse(e *endpoint.MysqlTarget) diag.Diagnostics {
 	var diag diag.Diagnostics
 
+	if m.Connection == nil {
+		m.Connection = &endpointMysqlConnection{}
+	}
 	m.Connection.parse(e.Connection)
 	// m.SecurityGroups = convertSliceToTFStrings(e.SecurityGroups)
 
-	m.Database = types.StringValue(e.Database)
-	m.User = types.StringValue(e.User)
+	m.Database = parseOptionalString(m.Database, e.Database)
+	m.User = parseOptionalString(m.User, e.User)
 	m.SqlMode = types.StringValue(e.SqlMode)
 	m.SkipConstraintCheck = types.BoolValue(e.SkipConstraintChecks)
 	m.Timezone = types.StringValue(e.Timezone)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -151,3 +152,85 @@ resource "doublecloud_transfer_endpoint" %[2]q {
 }
 `, testEMysqlSourceName, testEMysqlTargetName, testProjectId)
 }
+
+func mysqlOnPremiseConnection() *endpointMysqlConnection {
+	return &endpointMysqlConnection{OnPremise: &endpointMysqlOnPremise{
+		Hosts: []types.String{types.StringValue("leader-0.company.tech")},
+		Port:  types.Int64Value(3306),
+	}}
+}
+
+var mysqlRoundTripCases = []endpointRoundTripCase{
+	{
+		name: "mysql_source",
+		base: func() *endpointSettings {
+			return &endpointSettings{MysqlSource: &endpointMysqlSourceSettings{
+				Connection:      mysqlOnPremiseConnection(),
+				Database:        types.StringValue("production"),
+				ServiceDatabase: types.StringUnknown(),
+				Timezone:        types.StringUnknown(),
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.MysqlSource.User = types.StringValue("dc-transfer") },
+			func(s *endpointSettings) { s.MysqlSource.Password = types.StringValue("foobar123") },
+			func(s *endpointSettings) { s.MysqlSource.ServiceDatabase = types.StringValue("service") },
+			func(s *endpointSettings) { s.MysqlSource.Timezone = types.StringValue("Africa/Johannesburg") },
+			func(s *endpointSettings) {
+				s.MysqlSource.IncludeTablesRegex = []types.String{types.StringValue("prod.users")}
+			},
+			func(s *endpointSettings) {
+				s.MysqlSource.ExcludeTablesRegex = []types.String{types.StringValue("prod.tmp_.*"), types.StringValue("prod.log")}
+			},
+			func(s *endpointSettings) { s.MysqlSource.Connection.OnPremise.TLSMode = &endpointTLSMode{} },
+			func(s *endpointSettings) {
+				s.MysqlSource.Connection.OnPremise.TLSMode = &endpointTLSMode{CACertificate: types.StringValue("-----BEGIN CERTIFICATE-----")}
+			},
+			func(s *endpointSettings) {
+				s.MysqlSource.ObjectTransferSettings = &endpointMysqlObjectTransferSettings{Tables: types.StringValue("AFTER_DATA")}
+			},
+			func(s *endpointSettings) {
+				s.MysqlSource.ObjectTransferSettings = &endpointMysqlObjectTransferSettings{
+					View:    types.StringValue("NEVER"),
+					Routine: types.StringValue("BEFORE_DATA"),
+					Trigger: types.StringValue("AFTER_DATA"),
+					Tables:  types.StringValue("BEFORE_DATA"),
+				}
+			},
+		},
+	},
+	{
+		name: "mysql_target",
+		base: func() *endpointSettings {
+			return &endpointSettings{MysqlTarget: &endpointMysqlTargetSettings{
+				Connection:          mysqlOnPremiseConnection(),
+				Database:            types.StringValue("production"),
+				SqlMode:             types.StringUnknown(),
+				SkipConstraintCheck: types.BoolUnknown(),
+				Timezone:            types.StringUnknown(),
+				CleanupPolicy:       types.StringUnknown(),
+				ServiceDatabase:     types.StringUnknown(),
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.MysqlTarget.User = types.StringValue("dc-transfer") },
+			func(s *endpointSettings) { s.MysqlTarget.Password = types.StringValue("foobar123") },
+			func(s *endpointSettings) { s.MysqlTarget.SqlMode = types.StringValue("NO_AUTO_VALUE_ON_ZERO") },
+			func(s *endpointSettings) { s.MysqlTarget.SkipConstraintCheck = types.BoolValue(true) },
+			func(s *endpointSettings) { s.MysqlTarget.Timezone = types.StringValue("Europe/Zurich") },
+			func(s *endpointSettings) { s.MysqlTarget.CleanupPolicy = types.StringValue("DROP") },
+			func(s *endpointSettings) { s.MysqlTarget.ServiceDatabase = types.StringValue("service") },
+			func(s *endpointSettings) {
+				s.MysqlTarget.Connection.OnPremise.TLSMode = &endpointTLSMode{CACertificate: types.StringValue("-----BEGIN CERTIFICATE-----")}
+			},
+		},
+	},
+}
+
+func TestTransferEndpointMysqlRoundTrip(t *testing.T) {
+	testEndpointRoundTripCases(t, mysqlRoundTripCases)
+}
+
+func FuzzTransferEndpointMysqlRoundTrip(f *testing.F) {
+	fuzzEndpointRoundTrip(f, mysqlRoundTripCases)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
 )
 
 type endpointPostgresSourceSettings struct {
@@ -407,54 +408,71 @@ func postgresTargetEndpointSettings(m *endpointPostgresTargetSettings) (*transfe
 func parseTransferEndpointPostgresSource(ctx context.Context, e *endpoint.PostgresSource, c *endpointPostgresSourceSettings) diag.Diagnostics {
 	var diag diag.Diagnostics
 
+	if c.Connection == nil {
+		c.Connection = &endpointPostgresConnection{}
+	}
 	parseTransferEndpointPostgresConnection(e.Connection, c.Connection)
-	c.Database = types.StringValue(e.Database)
-	c.User = types.StringValue(e.User)
+	c.Database = parseOptionalString(c.Database, e.Database)
+	c.User = parseOptionalString(c.User, e.User)
 
 	c.IncludeTables = convertSliceToTFStrings(e.IncludeTables)
 	c.ExcludeTables = convertSliceToTFStrings(e.ExcludeTables)
 	c.SlotByteLagLimit = types.Int64Value(e.SlotByteLagLimit)
 	c.ServiceSchema = types.StringValue(e.ServiceSchema)
 
-	// TODO: Fix bug with default empty block
-	// parse ObjectTransferSettings
+	// default settings are returned as an empty block, keep it missing unless configured
+	if s := e.ObjectTransferSettings; c.ObjectTransferSettings != nil || proto.Size(s) != 0 {
+		if c.ObjectTransferSettings == nil {
+			c.ObjectTransferSettings = &endpointPostgresObjectTransferSettings{}
+		}
+		parseTransferEndpointPostgresObjectTransferSettings(s, c.ObjectTransferSettings)
+	}
 	return diag
 }
 
+func parseTransferEndpointPostgresObjectTransferSettings(e *endpoint.PostgresObjectTransferSettings, m *endpointPostgresObjectTransferSettings) {
+	m.Sequence = parseOptionalEnum(m.Sequence, e.GetSequence())
+	m.SequenceOwnedBy = parseOptionalEnum(m.SequenceOwnedBy, e.GetSequenceOwnedBy())
+	m.SequenceSet = parseOptionalEnum(m.SequenceSet, e.GetSequenceSet())
+	m.Table = parseOptionalEnum(m.Table, e.GetTable())
+	m.PrimaryKey = parseOptionalEnum(m.PrimaryKey, e.GetPrimaryKey())
+	m.FkConstraint = parseOptionalEnum(m.FkConstraint, e.GetFkConstraint())
+	m.DefaultValues = parseOptionalEnum(m.DefaultValues, e.GetDefaultValues())
+	m.Constraint = parseOptionalEnum(m.Constraint, e.GetConstraint())
+	m.Index = parseOptionalEnum(m.Index, e.GetIndex())
+	m.View = parseOptionalEnum(m.View, e.GetView())
+	m.MaterializedView = parseOptionalEnum(m.MaterializedView, e.GetMaterializedView())
+	m.Function = parseOptionalEnum(m.Function, e.GetFunction())
+	m.Trigger = parseOptionalEnum(m.Trigger, e.GetTrigger())
+	m.Type = parseOptionalEnum(m.Type, e.GetType())
+	m.Rule = parseOptionalEnum(m.Rule, e.GetRule())
+	m.Collation = parseOptionalEnum(m.Collation, e.GetCollation())
+	m.Policy = parseOptionalEnum(m.Policy, e.GetPolicy())
+	m.Cast = parseOptionalEnum(m.Cast, e.GetCast())
+}
+
 func parseTransferEndpointPostgresTarget(ctx context.Context, e *endpoint.PostgresTarget, c *endpointPostgresTargetSettings) diag.Diagnostics {
 	var diag diag.Diagnostics
 
+	if c.Connection == nil {
+		c.Connection = &endpointPostgresConnection{}
+	}
 	parseTransferEndpointPostgresConnection(e.Connection, c.Connection)
 	// c.SecurityGroups = convertSliceToTFStrings(e.SecurityGroups)
-	c.Database = types.StringValue(e.Database)
-	c.User = types.StringValue(e.User)
+	c.Database = parseOptionalString(c.Database, e.Database)
+	c.User = parseOptionalString(c.User, e.User)
 	c.CleanupPolicy = types.StringValue(e.CleanupPolicy.String())
 
 	return diag
 }
 
 func parseTransferEndpointPostgresConnection(e *endpoint.PostgresConnection, m *endpointPostgresConnection) {
-	if e == nil {
-		m = nil
-	}
-
 	if on_premise := e.GetOnPremise(); on_premise != nil {
-		if m == nil {
-			m = &endpointPostgresConnection{}
-		}
-		if m.OnPremise.Hosts != nil {
-			m.OnPremise.Hosts = convertSliceToTFStrings(on_premise.Hosts)
-		}
-		if !m.OnPremise.Port.IsNull() {
-			m.OnPremise.Port = types.Int64Value(on_premise.Port)
-		}
-		if m.OnPremise.TLSMode != nil {
-			if disabled := on_premise.TlsMode.GetDisabled(); disabled != nil {
-				m.OnPremise.TLSMode = nil
-			}
-			if config := on_premise.TlsMode.GetEnabled(); config != nil {
-				m.OnPremise.TLSMode = &endpointTLSMode{CACertificate: types.StringValue(config.CaCertificate)}
-			}
+		if m.OnPremise == nil {
+			m.OnPremise = &endpointPostgresConnectionOnPremise{}
 		}
+		m.OnPremise.Hosts = convertSliceToTFStrings(on_premise.Hosts)
+		m.OnPremise.Port = parseOptionalInt64(m.OnPremise.Port, on_premise.Port)
+		m.OnPremise.TLSMode = parseTLSMode(on_premise.TlsMode, m.OnPremise.TLSMode)
 	}
 }
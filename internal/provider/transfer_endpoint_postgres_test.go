@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -152,3 +153,74 @@ resource "doublecloud_transfer_endpoint" %[2]q {
 }
 `, testEPgSourceName, testEPgTargetName, testProjectId)
 }
+
+func postgresOnPremiseConnection() *endpointPostgresConnection {
+	return &endpointPostgresConnection{OnPremise: &endpointPostgresConnectionOnPremise{
+		Hosts: []types.String{types.StringValue("leader-0.company.tech"), types.StringValue("follower-0.company.tech")},
+	}}
+}
+
+var postgresRoundTripCases = []endpointRoundTripCase{
+	{
+		name: "postgres_source",
+		base: func() *endpointSettings {
+			return &endpointSettings{PostgresSource: &endpointPostgresSourceSettings{
+				Connection:       postgresOnPremiseConnection(),
+				SlotByteLagLimit: types.Int64Unknown(),
+				ServiceSchema:    types.StringUnknown(),
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.PostgresSource.Connection.OnPremise.Port = types.Int64Value(5432) },
+			func(s *endpointSettings) { s.PostgresSource.Connection.OnPremise.TLSMode = &endpointTLSMode{} },
+			func(s *endpointSettings) { s.PostgresSource.Database = types.StringValue("production") },
+			func(s *endpointSettings) { s.PostgresSource.User = types.StringValue("dc-transfer") },
+			func(s *endpointSettings) { s.PostgresSource.Password = types.StringValue("foobar123") },
+			func(s *endpointSettings) {
+				s.PostgresSource.IncludeTables = []types.String{types.StringValue("public.users")}
+			},
+			func(s *endpointSettings) {
+				s.PostgresSource.ExcludeTables = []types.String{types.StringValue("public.tmp"), types.StringValue("public.log")}
+			},
+			func(s *endpointSettings) { s.PostgresSource.SlotByteLagLimit = types.Int64Value(8388608) },
+			func(s *endpointSettings) { s.PostgresSource.ServiceSchema = types.StringValue("transfer") },
+			func(s *endpointSettings) {
+				s.PostgresSource.ObjectTransferSettings = &endpointPostgresObjectTransferSettings{
+					Sequence:   types.StringValue("BEFORE_DATA"),
+					Table:      types.StringValue("BEFORE_DATA"),
+					PrimaryKey: types.StringValue("AFTER_DATA"),
+					Index:      types.StringValue("AFTER_DATA"),
+					Trigger:    types.StringValue("NEVER"),
+					Cast:       types.StringValue("NEVER"),
+				}
+			},
+		},
+	},
+	{
+		name: "postgres_target",
+		base: func() *endpointSettings {
+			return &endpointSettings{PostgresTarget: &endpointPostgresTargetSettings{
+				Connection:    postgresOnPremiseConnection(),
+				CleanupPolicy: types.StringUnknown(),
+			}}
+		},
+		optional: []func(*endpointSettings){
+			func(s *endpointSettings) { s.PostgresTarget.Connection.OnPremise.Port = types.Int64Value(5432) },
+			func(s *endpointSettings) {
+				s.PostgresTarget.Connection.OnPremise.TLSMode = &endpointTLSMode{CACertificate: types.StringValue("<pem>")}
+			},
+			func(s *endpointSettings) { s.PostgresTarget.Database = types.StringValue("production") },
+			func(s *endpointSettings) { s.PostgresTarget.User = types.StringValue("dc-transfer") },
+			func(s *endpointSettings) { s.PostgresTarget.Password = types.StringValue("foobar123") },
+			func(s *endpointSettings) { s.PostgresTarget.CleanupPolicy = types.StringValue("TRUNCATE") },
+		},
+	},
+}
+
+func TestTransferEndpointPostgresRoundTrip(t *testing.T) {
+	testEndpointRoundTripCases(t, postgresRoundTripCases)
+}
+
+func FuzzTransferEndpointPostgresRoundTrip(f *testing.F) {
+	fuzzEndpointRoundTrip(f, postgresRoundTripCases)
+}
@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -15,7 +17,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1"
-	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1/endpoint"
 	dcsdk "github.com/doublecloud/go-sdk"
 	dcgentf "github.com/doublecloud/go-sdk/gen/transfer"
 )
@@ -23,12 +24,14 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TransferEndpointResource{}
 var _ resource.ResourceWithImportState = &TransferEndpointResource{}
+var _ resource.ResourceWithModifyPlan = &TransferEndpointResource{}
 
 func NewTransferEndpointResource() resource.Resource {
 	return &TransferEndpointResource{}
 }
 
 type TransferEndpointResource struct {
+	config          *Config
 	sdk             *dcsdk.SDK
 	endpointService *dcgentf.EndpointServiceClient
 }
@@ -39,6 +42,14 @@ type TransferEndpointModel struct {
 	Name        types.String      `tfsdk:"name"`
 	Description types.String      `tfsdk:"description"`
 	Settings    *endpointSettings `tfsdk:"settings"`
+	Timeouts    timeouts.Value    `tfsdk:"timeouts"`
+}
+
+var transferEndpointTimeouts = resourceTimeouts{
+	Create: 10 * time.Minute,
+	Read:   5 * time.Minute,
+	Update: 10 * time.Minute,
+	Delete: 10 * time.Minute,
 }
 
 type endpointSettings struct {
@@ -74,11 +85,9 @@ func (r *TransferEndpointResource) Schema(ctx context.Context, req resource.Sche
 				},
 			},
 			"project_id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "Project identifier",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -112,6 +121,7 @@ func (r *TransferEndpointResource) Schema(ctx context.Context, req resource.Sche
 					"mongo_target":      transferEndpointMongoTargetSchema(),
 				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
 		},
 	}
 }
@@ -122,20 +132,25 @@ func (r *TransferEndpointResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	sdk, ok := req.ProviderData.(*dcsdk.SDK)
+	config, ok := req.ProviderData.(*Config)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *dcsdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.sdk = sdk
+	r.config = config
+	r.sdk = config.sdk
 	r.endpointService = r.sdk.Transfer().Endpoint()
 }
 
+func (r *TransferEndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanProjectId(ctx, r.config, req, resp)
+}
+
 func createEndpointRequest(m *TransferEndpointModel) (*transfer.CreateEndpointRequest, diag.Diagnostics) {
 	var diag diag.Diagnostics
 
@@ -170,6 +185,14 @@ func (r *TransferEndpointResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, transferEndpointTimeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	rq, diag := createEndpointRequest(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -177,24 +200,36 @@ func (r *TransferEndpointResource) Create(ctx context.Context, req resource.Crea
 	}
 	net, err := r.endpointService.Create(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(net, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
 	}
-	err = op.Wait(ctx)
+	data.Id = types.StringValue(op.ResourceId())
+
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		if op.ResourceId() != "" {
+			saveFailedCreate(ctx, resp, &data, err)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
+	}
+
+	resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	data.Id = types.StringValue(op.ResourceId())
 	// Update computed fields
 	{
 		rs, err := r.endpointService.Get(ctx, &transfer.GetEndpointRequest{EndpointId: data.Id.ValueString()})
 		if err != nil {
-			resp.Diagnostics.AddError("failed to get", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 			return
 		}
 		resp.Diagnostics.Append(data.parseTransferEndpoint(ctx, rs)...)
@@ -214,9 +249,22 @@ func (r *TransferEndpointResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, transferEndpointTimeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	rs, err := r.endpointService.Get(ctx, &transfer.GetEndpointRequest{EndpointId: data.Id.ValueString()})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get", err.Error())
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("transfer endpoint %s not found, removing from state", data.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 		return
 	}
 
@@ -248,6 +296,18 @@ func (r *TransferEndpointResource) Update(ctx context.Context, req resource.Upda
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, transferEndpointTimeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	rq, diag := updateEndpointRequest(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -255,17 +315,17 @@ func (r *TransferEndpointResource) Update(ctx context.Context, req resource.Upda
 	}
 	net, err := r.endpointService.Update(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(net, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
-	err = op.Wait(ctx)
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
 
@@ -283,6 +343,14 @@ func (r *TransferEndpointResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, transferEndpointTimeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	rq, diag := deleteEndpointRequest(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -290,14 +358,19 @@ func (r *TransferEndpointResource) Delete(ctx context.Context, req resource.Dele
 	}
 	rs, err := r.endpointService.Delete(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(rs, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+		return
+	}
+	err = waitOperation(ctx, op)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+		return
 	}
-	err = op.Wait(ctx)
 
 	tflog.Trace(ctx, fmt.Sprintf("deleted endpoint: %s", data.Id))
 }
@@ -409,91 +482,68 @@ func (data *TransferEndpointModel) parseTransferEndpoint(ctx context.Context, e
 	data.ProjectID = types.StringValue(e.ProjectId)
 	data.Description = types.StringValue(e.Description)
 
-	if settings := e.Settings.GetClickhouseTarget(); settings != nil {
-
-		// TODO: move parsing to clickhouse file
-		if data.Settings.ClickhouseTarget == nil {
-			data.Settings.ClickhouseTarget = &endpointClickhouseTargetSettings{}
-		}
-		data.Settings.ClickhouseTarget.ClickhouseClusterName = types.StringValue(settings.ClickhouseClusterName)
-		data.Settings.ClickhouseTarget.ClickhouseCleanupPolicy = types.StringValue(endpoint.CleanupPolicy_name[int32(settings.CleanupPolicy.Number())])
-
-		if settings.AltNames != nil {
-			data.Settings.ClickhouseTarget.AltNames = make([]altName, len(settings.AltNames))
-			for i := 0; i < len(settings.AltNames); i++ {
-				data.Settings.ClickhouseTarget.AltNames[i] = altName{
-					FromName: types.StringValue(settings.AltNames[i].FromName),
-					ToName:   types.StringValue(settings.AltNames[i].ToName),
-				}
-			}
-		}
-		diag.Append(parseTransferEndpointClickhouseConnection(ctx, settings.Connection, data.Settings.ClickhouseTarget.Connection)...)
-
+	// settings are missing on import
+	if data.Settings == nil {
+		data.Settings = &endpointSettings{}
 	}
-	if settings := e.Settings.GetClickhouseSource(); settings != nil {
+
+	switch settings := e.Settings.GetSettings().(type) {
+	case *transfer.EndpointSettings_ClickhouseSource:
 		if data.Settings.ClickhouseSource == nil {
 			data.Settings.ClickhouseSource = &endpointClickhouseSourceSettings{}
 		}
-		diag.Append(parseTransferEndpointClickhouseConnection(ctx, settings.Connection, data.Settings.ClickhouseSource.Connection)...)
-		data.Settings.ClickhouseSource.IncludeTables = convertSliceToTFStrings(settings.IncludeTables)
-		data.Settings.ClickhouseSource.ExcludeTables = convertSliceToTFStrings(settings.ExcludeTables)
-	}
-	if settings := e.Settings.GetKafkaSource(); settings != nil {
+		diag.Append(data.Settings.ClickhouseSource.parse(ctx, settings.ClickhouseSource)...)
+	case *transfer.EndpointSettings_ClickhouseTarget:
+		if data.Settings.ClickhouseTarget == nil {
+			data.Settings.ClickhouseTarget = &endpointClickhouseTargetSettings{}
+		}
+		diag.Append(data.Settings.ClickhouseTarget.parse(ctx, settings.ClickhouseTarget)...)
+	case *transfer.EndpointSettings_KafkaSource:
 		if data.Settings.KafkaSource == nil {
 			data.Settings.KafkaSource = &endpointKafkaSourceSettings{}
 		}
-		diag.Append(parseTransferEndpointKafkaSource(ctx, settings, data.Settings.KafkaSource)...)
-	}
-	if settings := e.Settings.GetKafkaTarget(); settings != nil {
+		diag.Append(parseTransferEndpointKafkaSource(ctx, settings.KafkaSource, data.Settings.KafkaSource)...)
+	case *transfer.EndpointSettings_KafkaTarget:
 		if data.Settings.KafkaTarget == nil {
 			data.Settings.KafkaTarget = &endpointKafkaTargetSettings{}
 		}
-		diag.Append(parseTransferEndpointKafkaTarget(ctx, settings, data.Settings.KafkaTarget)...)
-	}
-	if settings := e.Settings.GetPostgresSource(); settings != nil {
+		diag.Append(parseTransferEndpointKafkaTarget(ctx, settings.KafkaTarget, data.Settings.KafkaTarget)...)
+	case *transfer.EndpointSettings_PostgresSource:
 		if data.Settings.PostgresSource == nil {
 			data.Settings.PostgresSource = &endpointPostgresSourceSettings{}
 		}
-		diag.Append(parseTransferEndpointPostgresSource(ctx, settings, data.Settings.PostgresSource)...)
-	}
-	if settings := e.Settings.GetPostgresTarget(); settings != nil {
+		diag.Append(parseTransferEndpointPostgresSource(ctx, settings.PostgresSource, data.Settings.PostgresSource)...)
+	case *transfer.EndpointSettings_PostgresTarget:
 		if data.Settings.PostgresTarget == nil {
 			data.Settings.PostgresTarget = &endpointPostgresTargetSettings{}
 		}
-		diag.Append(parseTransferEndpointPostgresTarget(ctx, settings, data.Settings.PostgresTarget)...)
-	}
-	if settings := e.Settings.GetMysqlSource(); settings != nil {
+		diag.Append(parseTransferEndpointPostgresTarget(ctx, settings.PostgresTarget, data.Settings.PostgresTarget)...)
+	case *transfer.EndpointSettings_MysqlSource:
 		if data.Settings.MysqlSource == nil {
 			data.Settings.MysqlSource = &endpointMysqlSourceSettings{}
 		}
-		diag.Append(data.Settings.MysqlSource.parse(settings)...)
-	}
-	if settings := e.Settings.GetMysqlTarget(); settings != nil {
+		diag.Append(data.Settings.MysqlSource.parse(settings.MysqlSource)...)
+	case *transfer.EndpointSettings_MysqlTarget:
 		if data.Settings.MysqlTarget == nil {
 			data.Settings.MysqlTarget = &endpointMysqlTargetSettings{}
 		}
-		diag.Append(data.Settings.MysqlTarget.parse(settings)...)
-	}
-	if settings := e.Settings.GetMongoSource(); settings != nil {
+		diag.Append(data.Settings.MysqlTarget.parse(settings.MysqlTarget)...)
+	case *transfer.EndpointSettings_MongoSource:
 		if data.Settings.MongoSource == nil {
 			data.Settings.MongoSource = &endpointMongoSourceSettings{}
 		}
-		diag.Append(data.Settings.MongoSource.parse(settings)...)
-	}
-	if settings := e.Settings.GetMongoTarget(); settings != nil {
+		diag.Append(data.Settings.MongoSource.parse(settings.MongoSource)...)
+	case *transfer.EndpointSettings_MongoTarget:
 		if data.Settings.MongoTarget == nil {
 			data.Settings.MongoTarget = &endpointMongoTargetSettings{}
 		}
-		diag.Append(data.Settings.MongoTarget.parse(settings)...)
-	}
-	if settings := e.Settings.GetS3Source(); settings != nil {
+		diag.Append(data.Settings.MongoTarget.parse(settings.MongoTarget)...)
+	case *transfer.EndpointSettings_S3Source:
 		if data.Settings.S3Source == nil {
 			data.Settings.S3Source = &endpointS3SourceSettings{}
 		}
-		diag.Append(data.Settings.S3Source.parse(settings)...)
-	}
-
-	if data.Settings == nil {
+		diag.Append(data.Settings.S3Source.parse(settings.S3Source)...)
+	default:
 		diag.AddError("failed to parse", "unknown settings type")
 	}
 
@@ -1,12 +1,20 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
+	"testing"
 
-	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1"
+	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1/endpoint"
 )
 
 func init() {
@@ -47,3 +55,143 @@ func sweepTransferEndpoint(conf *Config, t *transfer.Endpoint) error {
 	}
 	return op.Wait(conf.ctx)
 }
+
+// endpointRoundTripCase describes settings of one endpoint type for the
+// convert/parse round-trip tests.
+type endpointRoundTripCase struct {
+	name string
+	// base builds the smallest valid settings
+	base func() *endpointSettings
+	// optional fields, any combination of them applied to base must be valid
+	optional []func(*endpointSettings)
+}
+
+func (c endpointRoundTripCase) build(mask uint64) *TransferEndpointModel {
+	s := c.base()
+	for i, f := range c.optional {
+		if mask&(1<<i) != 0 {
+			f(s)
+		}
+	}
+	return &TransferEndpointModel{Settings: s}
+}
+
+// testEndpointRoundTrip checks both directions for the settings built with mask:
+// model -> API -> model refreshes the same model without changes,
+// API -> model -> API restores the same settings when parsed from scratch as on import.
+func testEndpointRoundTrip(t *testing.T, c endpointRoundTripCase, mask uint64) {
+	t.Helper()
+	ctx := context.Background()
+
+	settings, diags := transferEndpointSettings(c.build(mask))
+	if diags.HasError() {
+		t.Errorf("%s: failed to convert: %v", c.name, diags)
+		return
+	}
+	e := &transfer.Endpoint{Settings: settings}
+
+	want, got := c.build(mask), c.build(mask)
+	if diags := got.parseTransferEndpoint(ctx, e); diags.HasError() {
+		t.Errorf("%s: failed to parse: %v", c.name, diags)
+		return
+	}
+	for _, d := range diffEndpointModel("settings", reflect.ValueOf(want.Settings), reflect.ValueOf(got.Settings)) {
+		t.Errorf("%s (optional %b): model -> API -> model: %s", c.name, mask, d)
+	}
+
+	imported := &TransferEndpointModel{}
+	if diags := imported.parseTransferEndpoint(ctx, e); diags.HasError() {
+		t.Errorf("%s: failed to parse from scratch: %v", c.name, diags)
+		return
+	}
+	restored, diags := transferEndpointSettings(imported)
+	if diags.HasError() {
+		t.Errorf("%s: failed to convert parsed model: %v", c.name, diags)
+		return
+	}
+	// secrets are never returned by the API
+	if d := cmp.Diff(settings, restored, protocmp.Transform(), protocmp.IgnoreMessages(&endpoint.Secret{})); d != "" {
+		t.Errorf("%s (optional %b): API -> model -> API (-want +got):\n%s", c.name, mask, d)
+	}
+}
+
+// testEndpointRoundTripCases checks each case without optional fields,
+// with every optional field alone and with all of them.
+func testEndpointRoundTripCases(t *testing.T, cases []endpointRoundTripCase) {
+	for _, c := range cases {
+		testEndpointRoundTrip(t, c, 0)
+		for i := range c.optional {
+			testEndpointRoundTrip(t, c, 1<<i)
+		}
+		testEndpointRoundTrip(t, c, ^uint64(0))
+	}
+}
+
+// fuzzEndpointRoundTrip runs the round-trip on random combinations of optional fields.
+func fuzzEndpointRoundTrip(f *testing.F, cases []endpointRoundTripCase) {
+	for i := range cases {
+		f.Add(uint8(i), uint64(0))
+		f.Add(uint8(i), ^uint64(0))
+	}
+	f.Fuzz(func(t *testing.T, i uint8, mask uint64) {
+		c := cases[int(i)%len(cases)]
+		testEndpointRoundTrip(t, c, mask)
+	})
+}
+
+// diffEndpointModel reports attributes which differ between models, paths use tfsdk names.
+// Unknown values in want may be refreshed to anything but must not stay unknown.
+func diffEndpointModel(p string, want, got reflect.Value) []string {
+	if v, ok := want.Interface().(attr.Value); ok {
+		g := got.Interface().(attr.Value)
+		switch {
+		case g.IsUnknown():
+			return []string{fmt.Sprintf("%s: left unknown", p)}
+		case v.IsUnknown() || v.Equal(g):
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: want %s, got %s", p, v, g)}
+	}
+
+	switch want.Kind() {
+	case reflect.Pointer:
+		switch {
+		case want.IsNil() && got.IsNil():
+			return nil
+		case want.IsNil():
+			return []string{fmt.Sprintf("%s: unexpected block", p)}
+		case got.IsNil():
+			return []string{fmt.Sprintf("%s: block dropped", p)}
+		}
+		return diffEndpointModel(p, want.Elem(), got.Elem())
+	case reflect.Slice:
+		// null list attribute differs from an empty one, while absent list blocks are always empty
+		isBlock := want.Type().Elem().Kind() == reflect.Struct
+		if !isBlock && want.IsNil() != got.IsNil() {
+			return []string{fmt.Sprintf("%s: want %s, got %s", p, sliceString(want), sliceString(got))}
+		}
+		if want.Len() != got.Len() {
+			return []string{fmt.Sprintf("%s: want %d elements, got %d", p, want.Len(), got.Len())}
+		}
+		var diffs []string
+		for i := 0; i < want.Len(); i++ {
+			diffs = append(diffs, diffEndpointModel(fmt.Sprintf("%s[%d]", p, i), want.Index(i), got.Index(i))...)
+		}
+		return diffs
+	case reflect.Struct:
+		var diffs []string
+		for i := 0; i < want.NumField(); i++ {
+			name := want.Type().Field(i).Tag.Get("tfsdk")
+			diffs = append(diffs, diffEndpointModel(p+"."+name, want.Field(i), got.Field(i))...)
+		}
+		return diffs
+	}
+	panic(fmt.Sprintf("%s: unsupported model type %s", p, want.Type()))
+}
+
+func sliceString(v reflect.Value) string {
+	if v.IsNil() {
+		return "<null>"
+	}
+	return fmt.Sprint(v.Interface())
+}
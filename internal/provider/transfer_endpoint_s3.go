@@ -218,7 +218,7 @@ func (m *endpointS3Format) convert() (*endpoint_airbyte.S3Source_Format, diag.Di
 	}
 	if v := m.Avro; v != nil {
 		return &endpoint_airbyte.S3Source_Format{
-			Format: &endpoint_airbyte.S3Source_Format_Avro{},
+			Format: &endpoint_airbyte.S3Source_Format_Avro{Avro: &endpoint_airbyte.S3Source_Avro{}},
 		}, diags
 	}
 	if v := m.Jsonl; v != nil {
@@ -278,8 +278,18 @@ func (m *endpointS3SourceSettings) parse(e *endpoint_airbyte.S3Source) diag.Diag
 	m.PathPattern = types.StringValue(e.PathPattern)
 	m.Schema = types.StringValue(e.Schema)
 
-	diags.Append(m.Format.parse(e.Format)...)
-	diags.Append(m.Provider.parse(e.Provider)...)
+	if e.Format != nil {
+		if m.Format == nil {
+			m.Format = &endpointS3Format{}
+		}
+		diags.Append(m.Format.parse(e.Format)...)
+	}
+	if e.Provider != nil {
+		if m.Provider == nil {
+			m.Provider = &endpointS3Provider{}
+		}
+		diags.Append(m.Provider.parse(e.Provider)...)
+	}
 
 	return diags
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -290,3 +291,115 @@ resource "doublecloud_transfer_endpoint" %[1]q {
 }
 `, testES3JsonlSourceName, testProjectId)
 }
+
+// s3SourceSettings leaves computed attributes unknown, as they are planned when not configured.
+func s3SourceSettings(format *endpointS3Format) *endpointSettings {
+	return &endpointSettings{S3Source: &endpointS3SourceSettings{
+		Dataset:     types.StringValue("events"),
+		PathPattern: types.StringValue("/events/"),
+		Schema:      types.StringUnknown(),
+		Format:      format,
+		Provider: &endpointS3Provider{
+			Bucket:             types.StringUnknown(),
+			AwsAccessKeyId:     types.StringUnknown(),
+			AwsSecretAccessKey: types.StringUnknown(),
+			PathPrefix:         types.StringUnknown(),
+			Endpoint:           types.StringUnknown(),
+			UseSSL:             types.BoolUnknown(),
+			VerifySSLCert:      types.BoolUnknown(),
+		},
+	}}
+}
+
+var s3ProviderRoundTripOptions = []func(*endpointSettings){
+	func(s *endpointSettings) { s.S3Source.Schema = types.StringValue(`{"id": "integer"}`) },
+	func(s *endpointSettings) { s.S3Source.Provider.Bucket = types.StringValue("cdc-production") },
+	func(s *endpointSettings) { s.S3Source.Provider.AwsAccessKeyId = types.StringValue("AKIAEXAMPLE") },
+	func(s *endpointSettings) { s.S3Source.Provider.AwsSecretAccessKey = types.StringValue("secret") },
+	func(s *endpointSettings) { s.S3Source.Provider.PathPrefix = types.StringValue("cdc/") },
+	func(s *endpointSettings) { s.S3Source.Provider.Endpoint = types.StringValue("s3.company.tech") },
+	func(s *endpointSettings) { s.S3Source.Provider.UseSSL = types.BoolValue(false) },
+	func(s *endpointSettings) { s.S3Source.Provider.VerifySSLCert = types.BoolValue(true) },
+}
+
+var s3RoundTripCases = []endpointRoundTripCase{
+	{
+		name: "s3_source_csv",
+		base: func() *endpointSettings {
+			return s3SourceSettings(&endpointS3Format{Csv: &endpointS3FormatCSV{
+				Delimiter:               types.StringUnknown(),
+				QuoteChar:               types.StringUnknown(),
+				EscapeChar:              types.StringUnknown(),
+				Encoding:                types.StringUnknown(),
+				DoubleQuote:             types.BoolUnknown(),
+				NewlinesInValues:        types.BoolUnknown(),
+				BlockSize:               types.Int64Unknown(),
+				AdditionalReaderOptions: types.StringUnknown(),
+				AdvancedOptions:         types.StringUnknown(),
+			}})
+		},
+		optional: append([]func(*endpointSettings){
+			func(s *endpointSettings) { s.S3Source.Format.Csv.Delimiter = types.StringValue(";") },
+			func(s *endpointSettings) { s.S3Source.Format.Csv.QuoteChar = types.StringValue(`"`) },
+			func(s *endpointSettings) { s.S3Source.Format.Csv.EscapeChar = types.StringValue(`\\`) },
+			func(s *endpointSettings) { s.S3Source.Format.Csv.Encoding = types.StringValue("utf8") },
+			func(s *endpointSettings) { s.S3Source.Format.Csv.DoubleQuote = types.BoolValue(true) },
+			func(s *endpointSettings) { s.S3Source.Format.Csv.NewlinesInValues = types.BoolValue(false) },
+			func(s *endpointSettings) { s.S3Source.Format.Csv.BlockSize = types.Int64Value(1024) },
+			func(s *endpointSettings) {
+				s.S3Source.Format.Csv.AdditionalReaderOptions = types.StringValue(`{"strings_can_be_null": true}`)
+			},
+			func(s *endpointSettings) {
+				s.S3Source.Format.Csv.AdvancedOptions = types.StringValue(`{"skip_rows": 1}`)
+			},
+		}, s3ProviderRoundTripOptions...),
+	},
+	{
+		name: "s3_source_parquet",
+		base: func() *endpointSettings {
+			return s3SourceSettings(&endpointS3Format{Parquet: &endpointS3FormatParquet{
+				BufferSize: types.Int64Unknown(),
+				BatchSize:  types.Int64Unknown(),
+			}})
+		},
+		optional: append([]func(*endpointSettings){
+			func(s *endpointSettings) { s.S3Source.Format.Parquet.BufferSize = types.Int64Value(2) },
+			func(s *endpointSettings) { s.S3Source.Format.Parquet.BatchSize = types.Int64Value(1024) },
+			func(s *endpointSettings) {
+				s.S3Source.Format.Parquet.Columns = []types.String{types.StringValue("id"), types.StringValue("created_at")}
+			},
+		}, s3ProviderRoundTripOptions...),
+	},
+	{
+		name: "s3_source_avro",
+		base: func() *endpointSettings {
+			return s3SourceSettings(&endpointS3Format{Avro: &endpointS3FormatAvro{}})
+		},
+		optional: s3ProviderRoundTripOptions,
+	},
+	{
+		name: "s3_source_jsonl",
+		base: func() *endpointSettings {
+			return s3SourceSettings(&endpointS3Format{Jsonl: &endpointS3FormatJsonl{
+				NewlinesInValues:         types.BoolUnknown(),
+				UnexpectedFieldBehaviour: types.StringUnknown(),
+				BlockSize:                types.Int64Unknown(),
+			}})
+		},
+		optional: append([]func(*endpointSettings){
+			func(s *endpointSettings) { s.S3Source.Format.Jsonl.NewlinesInValues = types.BoolValue(true) },
+			func(s *endpointSettings) {
+				s.S3Source.Format.Jsonl.UnexpectedFieldBehaviour = types.StringValue("UNEXPECTED_FIELD_BEHAVIOR_INFER")
+			},
+			func(s *endpointSettings) { s.S3Source.Format.Jsonl.BlockSize = types.Int64Value(1024) },
+		}, s3ProviderRoundTripOptions...),
+	},
+}
+
+func TestTransferEndpointS3RoundTrip(t *testing.T) {
+	testEndpointRoundTripCases(t, s3RoundTripCases)
+}
+
+func FuzzTransferEndpointS3RoundTrip(f *testing.F) {
+	fuzzEndpointRoundTrip(f, s3RoundTripCases)
+}
@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -26,26 +28,36 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TransferEndpointResource{}
 var _ resource.ResourceWithImportState = &TransferEndpointResource{}
+var _ resource.ResourceWithModifyPlan = &TransferResource{}
 
 func NewTransferResource() resource.Resource {
 	return &TransferResource{}
 }
 
 type TransferResource struct {
-	sdk *dcsdk.SDK
+	config *Config
+	sdk    *dcsdk.SDK
 	// endpointService *dcgentf.EndpointServiceClient
 	transferService *dcgentf.TransferServiceClient
 }
 
 type TransferResourceModel struct {
-	Id          types.String `tfsdk:"id"`
-	ProjectID   types.String `tfsdk:"project_id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Source      types.String `tfsdk:"source"`
-	Target      types.String `tfsdk:"target"`
-	Type        types.String `tfsdk:"type"`
-	Activated   types.Bool   `tfsdk:"activated"`
+	Id          types.String   `tfsdk:"id"`
+	ProjectID   types.String   `tfsdk:"project_id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Source      types.String   `tfsdk:"source"`
+	Target      types.String   `tfsdk:"target"`
+	Type        types.String   `tfsdk:"type"`
+	Activated   types.Bool     `tfsdk:"activated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+var transferTimeouts = resourceTimeouts{
+	Create: 10 * time.Minute,
+	Read:   5 * time.Minute,
+	Update: 10 * time.Minute,
+	Delete: 10 * time.Minute,
 }
 
 func (r *TransferResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -66,11 +78,9 @@ func (r *TransferResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"project_id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "Project identifier",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -112,6 +122,9 @@ func (r *TransferResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "Activation of transfer",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
 	}
 }
 
@@ -121,21 +134,26 @@ func (r *TransferResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	sdk, ok := req.ProviderData.(*dcsdk.SDK)
+	config, ok := req.ProviderData.(*Config)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *dcsdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.sdk = sdk
+	r.config = config
+	r.sdk = config.sdk
 	// r.endpointService = r.sdk.Transfer().Endpoint()
 	r.transferService = r.sdk.Transfer().Transfer()
 }
 
+func (r *TransferResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanProjectId(ctx, r.config, req, resp)
+}
+
 func createTransferRequest(m *TransferResourceModel) (*transfer.CreateTransferRequest, diag.Diagnostics) {
 	var diag diag.Diagnostics
 
@@ -180,22 +198,24 @@ func (r *TransferResource) setActivation(ctx context.Context, m *TransferResourc
 	}
 
 	if err != nil {
-		diags.AddError("failed to activate", err.Error())
+		diags.Append(apiErrorDiagnostics("failed to activate", err)...)
 		return diags
 	}
 	op, err := r.sdk.WrapOperation(dcOp, err)
 	if err != nil {
-		diags.AddError("failed to activate", err.Error())
+		diags.Append(apiErrorDiagnostics("failed to activate", err)...)
 		return diags
 	}
-	err = op.Wait(ctx)
+	err = waitOperation(ctx, op)
 	if err != nil {
-		diags.AddError("failed to activate", err.Error())
+		diags.Append(apiErrorDiagnostics("failed to activate", err)...)
 	}
 	return diags
 }
 
 func (r *TransferResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.config.listings.invalidate("transfer")
+
 	var data *TransferResourceModel
 
 	// Read Terraform plan data into the model
@@ -205,6 +225,14 @@ func (r *TransferResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, transferTimeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	rq, diag := createTransferRequest(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -212,21 +240,35 @@ func (r *TransferResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	rs, err := r.transferService.Create(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(rs, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
 	}
-	err = op.Wait(ctx)
+	data.Id = types.StringValue(op.ResourceId())
+
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		if op.ResourceId() != "" {
+			saveFailedCreate(ctx, resp, &data, err)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
+		return
 	}
 
-	data.Id = types.StringValue(op.ResourceId())
+	resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	r.setActivation(ctx, data)
+	resp.Diagnostics.Append(r.setActivation(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -242,14 +284,28 @@ func (r *TransferResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, transferTimeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	rs, err := r.transferService.Get(ctx, &transfer.GetTransferRequest{
 		TransferId: data.Id.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get", err.Error())
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("transfer %s not found, removing from state", data.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 		return
 	}
 	data.Name = types.StringValue(rs.Name)
+	data.ProjectID = types.StringValue(rs.ProjectId)
 	data.Description = types.StringValue(rs.Description)
 	data.Source = types.StringValue(rs.Source.Id)
 	data.Target = types.StringValue(rs.Target.Id)
@@ -260,38 +316,57 @@ func (r *TransferResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *TransferResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.config.listings.invalidate("transfer")
+
 	var data *TransferResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, transferTimeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	rs, err := r.transferService.Update(ctx, &transfer.UpdateTransferRequest{
 		TransferId:  data.Id.ValueString(),
 		Description: data.Description.ValueString(),
 		Name:        data.Name.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(rs, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
-	err = op.Wait(ctx)
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
 
-	r.setActivation(ctx, data)
+	resp.Diagnostics.Append(r.setActivation(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *TransferResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.config.listings.invalidate("transfer")
+
 	var data *TransferResourceModel
 
 	// Read Terraform prior state data into the model
@@ -301,6 +376,14 @@ func (r *TransferResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, transferTimeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	rq, diag := deleteTransferRequest(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -308,14 +391,19 @@ func (r *TransferResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 	rs, err := r.transferService.Delete(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(rs, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+		return
+	}
+	err = waitOperation(ctx, op)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
+		return
 	}
-	err = op.Wait(ctx)
 
 	tflog.Trace(ctx, fmt.Sprintf("deleted endpoint: %s", data.Id))
 }
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/doublecloud/go-genproto/doublecloud/transfer/v1"
+	dcsdk "github.com/doublecloud/go-sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TransfersDataSource{}
+
+func NewTransfersDataSource() datasource.DataSource {
+	return &TransfersDataSource{}
+}
+
+type TransfersDataSource struct {
+	config *Config
+	sdk    *dcsdk.SDK
+}
+
+type TransfersDataSourceModel struct {
+	Id        types.String         `tfsdk:"id"`
+	ProjectID types.String         `tfsdk:"project_id"`
+	NameRegex types.String         `tfsdk:"name_regex"`
+	Status    types.String         `tfsdk:"status"`
+	Transfers []transfersItemModel `tfsdk:"transfers"`
+}
+
+type transfersItemModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+	Status      types.String `tfsdk:"status"`
+	SourceId    types.String `tfsdk:"source_id"`
+	TargetId    types.String `tfsdk:"target_id"`
+}
+
+func (d *TransfersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_transfers"
+}
+
+func (d *TransfersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	// Transfer statuses have no common prefix and are reported as is, like by doublecloud_transfer
+	statuses := enumNames(transfer.TransferStatus_name, "")
+	for i, s := range statuses {
+		statuses[i] = strings.ToUpper(s)
+	}
+	attrs := listFilterAttributes("transfer", statuses, "name_regex", "status")
+	attrs["transfers"] = schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "Transfers matching the filters",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Transfer identifier",
+				},
+				"name": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Name of transfer",
+				},
+				"description": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Description of transfer",
+				},
+				"type": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Type of transfer",
+				},
+				"status": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Status of transfer",
+				},
+				"source_id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Source endpoint identifier",
+				},
+				"target_id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Target endpoint identifier",
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Transfers data source lists transfers of the project",
+		Attributes:          attrs,
+	}
+}
+
+func (d *TransfersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*Config)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.config = config
+	d.sdk = config.sdk
+}
+
+func (d *TransfersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TransfersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectId, diags := d.config.projectId(data.ProjectID)
+	resp.Diagnostics.Append(diags...)
+	nameRegex, diags := compileNameRegex(data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = projectId
+	data.ProjectID = projectId
+
+	data.Transfers = []transfersItemModel{}
+	items, err := d.config.transfers(ctx, projectId.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to list", err)...)
+		return
+	}
+	for _, t := range items {
+		if !matchNameRegex(nameRegex, t.Name) || !matchFilter(data.Status, t.Status.String()) {
+			continue
+		}
+		data.Transfers = append(data.Transfers, transfersItemModel{
+			Id:          types.StringValue(t.Id),
+			Name:        types.StringValue(t.Name),
+			Description: types.StringValue(t.Description),
+			Type:        types.StringValue(t.Type.String()),
+			Status:      types.StringValue(t.Status.String()),
+			SourceId:    types.StringValue(t.GetSource().GetId()),
+			TargetId:    types.StringValue(t.GetTarget().GetId()),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
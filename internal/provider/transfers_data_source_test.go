@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTransfersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccTransfersDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.doublecloud_transfers.test", "transfers.#", "1"),
+					resource.TestCheckResourceAttr("data.doublecloud_transfers.test", "transfers.0.name", testDSTransferName),
+					resource.TestCheckResourceAttr("data.doublecloud_transfers.test", "transfers.0.status", "RUNNING"),
+					resource.TestCheckResourceAttrSet("data.doublecloud_transfers.test", "transfers.0.source_id"),
+					resource.TestCheckResourceAttrSet("data.doublecloud_transfers.test", "transfers.0.target_id"),
+					resource.TestCheckResourceAttr("data.doublecloud_transfers.done", "transfers.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTransfersDataSourceConfig() string {
+	return fmt.Sprintf(`
+data "doublecloud_transfers" "test" {
+	project_id = %[1]q
+	name_regex = "^%[2]s$"
+	status = "running"
+}
+
+data "doublecloud_transfers" "done" {
+	project_id = %[1]q
+	name_regex = "^%[2]s$"
+	status = "DONE"
+}`, testProjectId, testDSTransferName)
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// tlsConfig returns TLS settings for API connections or nil to use defaults.
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	if c.CABundleFile == "" {
+		return nil, nil
+	}
+	bundle, err := os.ReadFile(c.CABundleFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", c.CABundleFile)
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// dialOptions returns gRPC options to reach the configured endpoint,
+// optionally through HTTPS proxy.
+func (c *Config) dialOptions() ([]grpc.DialOption, error) {
+	if c.Endpoint == "" && c.HTTPSProxy == "" {
+		return nil, nil
+	}
+
+	var opts []grpc.DialOption
+	var proxy *url.URL
+	if c.HTTPSProxy != "" {
+		u, err := url.Parse(c.HTTPSProxy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse https_proxy: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("unsupported https_proxy scheme %q", u.Scheme)
+		}
+		proxy = u
+	}
+	if c.Endpoint != "" {
+		if _, _, err := net.SplitHostPort(c.Endpoint); err != nil {
+			return nil, fmt.Errorf("endpoint must be in host:port format: %w", err)
+		}
+		// All services are served by the same endpoint, so TLS must verify it
+		// instead of the default service address.
+		opts = append(opts, grpc.WithAuthority(c.Endpoint))
+	}
+
+	opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		if c.Endpoint != "" {
+			addr = c.Endpoint
+		}
+		if proxy != nil {
+			return dialHTTPSProxy(ctx, proxy, addr)
+		}
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", addr)
+	}))
+	return opts, nil
+}
+
+// dialHTTPSProxy opens a tunnel to addr using HTTP CONNECT method.
+func dialHTTPSProxy(ctx context.Context, proxy *url.URL, addr string) (net.Conn, error) {
+	proxyAddr := proxy.Host
+	if proxy.Port() == "" {
+		port := "80"
+		if proxy.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddr = net.JoinHostPort(proxy.Hostname(), port)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial proxy %s: %w", proxyAddr, err)
+	}
+	if proxy.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: proxy.Hostname()})
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	rq := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if u := proxy.User; u != nil {
+		password, _ := u.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(u.Username() + ":" + password))
+		rq.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := rq.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to write CONNECT request to proxy: %w", err)
+	}
+
+	br := bufio.NewReader(conn)
+	rs, err := http.ReadResponse(br, rq)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response from proxy: %w", err)
+	}
+	rs.Body.Close()
+	if rs.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy refused to connect to %s: %s", addr, rs.Status)
+	}
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn keeps bytes read ahead while parsing proxy response.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestDialHTTPSProxy(t *testing.T) {
+	t.Parallel()
+
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+	go func() {
+		conn, err := target.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("pong"))
+	}()
+
+	proxy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxy.Close()
+	go func() {
+		conn, err := proxy.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		rq, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil || rq.Method != http.MethodConnect || rq.Host != target.Addr().String() {
+			conn.Write([]byte("HTTP/1.1 400 Bad Request\r\n\r\n"))
+			return
+		}
+		upstream, err := net.Dial("tcp", rq.Host)
+		if err != nil {
+			return
+		}
+		defer upstream.Close()
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		io.Copy(conn, upstream)
+	}()
+
+	conn, err := dialHTTPSProxy(context.Background(), &url.URL{Scheme: "http", Host: proxy.Addr().String()}, target.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to dial through proxy: %v", err)
+	}
+	defer conn.Close()
+
+	data, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "pong" {
+		t.Errorf("unexpected data through tunnel: %q", data)
+	}
+}
+
+func TestConfigDialOptions(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []Config{
+		{Endpoint: "localhost"},
+		{HTTPSProxy: "socks5://localhost:1080"},
+		{CABundleFile: "testdata/missing.pem"},
+	} {
+		_, errDial := c.dialOptions()
+		_, errTLS := c.tlsConfig()
+		if errDial == nil && errTLS == nil {
+			t.Errorf("expected error for config %+v", c)
+		}
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -25,22 +27,31 @@ import (
 
 var _ resource.Resource = &WorkbookResource{}
 var _ resource.ResourceWithImportState = &WorkbookResource{}
+var _ resource.ResourceWithModifyPlan = &WorkbookResource{}
 
 func NewWorkbookResource() resource.Resource {
 	return &WorkbookResource{}
 }
 
 type WorkbookResource struct {
-	sdk *dcsdk.SDK
-	svc *dcgenvis.WorkbookServiceClient
+	config *Config
+	sdk    *dcsdk.SDK
+	svc    *dcgenvis.WorkbookServiceClient
 }
 
 type WorkbookResourceModel struct {
-	Id          types.String `tfsdk:"id"`
-	ProjectID   types.String `tfsdk:"project_id"`
-	Title       types.String `tfsdk:"title"`
-	Config      types.String `tfsdk:"config"`
-	Connections types.Set    `tfsdk:"connect"`
+	Id          types.String   `tfsdk:"id"`
+	ProjectID   types.String   `tfsdk:"project_id"`
+	Title       types.String   `tfsdk:"title"`
+	Config      types.String   `tfsdk:"config"`
+	Connections types.Set      `tfsdk:"connect"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+var workbookTimeouts = resourceTimeouts{
+	Create: 10 * time.Minute,
+	Update: 10 * time.Minute,
+	Delete: 10 * time.Minute,
 }
 
 func (r *WorkbookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,7 +71,8 @@ func (r *WorkbookResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"project_id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "Project identifier",
 			},
 			"title": schema.StringAttribute{
@@ -92,6 +104,7 @@ func (r *WorkbookResource) Schema(ctx context.Context, req resource.SchemaReques
 					},
 				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
 		},
 	}
 }
@@ -102,20 +115,25 @@ func (r *WorkbookResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	sdk, ok := req.ProviderData.(*dcsdk.SDK)
+	config, ok := req.ProviderData.(*Config)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *dcsdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.sdk = sdk
+	r.config = config
+	r.sdk = config.sdk
 	r.svc = r.sdk.Visualization().Workbook()
 }
 
+func (r *WorkbookResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanProjectId(ctx, r.config, req, resp)
+}
+
 func createWorkbookRequest(m *WorkbookResourceModel) (*visualization.CreateWorkbookRequest, diag.Diagnostics) {
 	rq := &visualization.CreateWorkbookRequest{}
 	rq.ProjectId = m.ProjectID.ValueString()
@@ -170,6 +188,14 @@ func (r *WorkbookResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, workbookTimeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// TODO: move to validation
 	if !json.Valid([]byte(data.Config.ValueString())) {
 		resp.Diagnostics.AddError("incorrect config format", data.Config.ValueString())
@@ -183,31 +209,40 @@ func (r *WorkbookResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	rs, err := r.svc.Create(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(rs, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 		return
 	}
-	err = op.Wait(ctx)
+	data.Id = types.StringValue(op.ResourceId())
+
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create", err.Error())
+		if op.ResourceId() != "" {
+			saveFailedCreate(ctx, resp, &data, err)
+			return
+		}
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 		return
 	}
 
-	data.Id = types.StringValue(op.ResourceId())
+	resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	getRequest, diag := getWorkbookResourceRequest(data)
 	if diag.HasError() {
-		resp.Diagnostics.AddError("failed to get", err.Error())
+		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	getResponse, err := r.svc.Get(ctx, getRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to get", err)...)
 		return
 	}
 	// Temporary hack to align json formats between Lens and Terraform
@@ -229,17 +264,17 @@ func (r *WorkbookResource) Create(ctx context.Context, req resource.CreateReques
 	for _, c := range connections {
 		rs, err := r.svc.CreateConnection(ctx, c)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to create", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 			return
 		}
 		op, err := r.sdk.WrapOperation(rs, err)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to create", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 			return
 		}
-		err = op.Wait(ctx)
+		err = waitOperation(ctx, op)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to create", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 			return
 		}
 	}
@@ -253,17 +288,17 @@ func (r *WorkbookResource) Create(ctx context.Context, req resource.CreateReques
 		}
 		rs, err := r.svc.Update(ctx, modifyReq)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to modify", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to modify", err)...)
 			return
 		}
 		op, err := r.sdk.WrapOperation(rs, err)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to modify", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to modify", err)...)
 			return
 		}
-		err = op.Wait(ctx)
+		err = waitOperation(ctx, op)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to modify", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to modify", err)...)
 			return
 		}
 	} else {
@@ -346,17 +381,17 @@ func (r *WorkbookResource) updateConnections(ctx context.Context, resp *resource
 	for _, c := range toCreate {
 		rs, err := r.svc.CreateConnection(ctx, c)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to create", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 			return
 		}
 		op, err := r.sdk.WrapOperation(rs, err)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to create", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 			return
 		}
-		err = op.Wait(ctx)
+		err = waitOperation(ctx, op)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to create", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to create", err)...)
 			return
 		}
 	}
@@ -364,17 +399,17 @@ func (r *WorkbookResource) updateConnections(ctx context.Context, resp *resource
 	for _, c := range toUpdate {
 		rs, err := r.svc.UpdateConnection(ctx, c)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to update", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 			return
 		}
 		op, err := r.sdk.WrapOperation(rs, err)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to update", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 			return
 		}
-		err = op.Wait(ctx)
+		err = waitOperation(ctx, op)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to update", err.Error())
+			resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 			return
 		}
 	}
@@ -387,6 +422,18 @@ func (r *WorkbookResource) Update(ctx context.Context, req resource.UpdateReques
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, workbookTimeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	r.updateConnections(ctx, resp, data)
 
 	rq, diag := updateWorkbookRequest(data)
@@ -396,17 +443,17 @@ func (r *WorkbookResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 	rs, err := r.svc.Update(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(rs, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
-	err = op.Wait(ctx)
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to update", err)...)
 		return
 	}
 
@@ -428,6 +475,14 @@ func (r *WorkbookResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, workbookTimeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	rq, diag := deleteWorkbookRequest(data)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
@@ -435,17 +490,17 @@ func (r *WorkbookResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 	rs, err := r.svc.Delete(ctx, rq)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
 		return
 	}
 	op, err := r.sdk.WrapOperation(rs, err)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
 		return
 	}
-	err = op.Wait(ctx)
+	err = waitOperation(ctx, op)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics("failed to delete", err)...)
 		return
 	}
 	tflog.Debug(ctx, fmt.Sprintf("deleted workbook: %v", data.Id))